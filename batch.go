@@ -0,0 +1,84 @@
+package piranhas
+
+import (
+	"reflect"
+)
+
+// pathNode is a node of the tree built from the path elements of several paths
+type pathNode struct {
+	// pathelement holds the single path element leading to the node
+	pathelement []string
+	children    []*pathNode
+	// indexes of the paths ending at this node
+	indexes []int
+}
+
+// GetPaths retrieves the interfaces for several paths like GetPathInterface and returns them in the order of the paths.
+// Paths sharing a prefix like "address.street" and "address.city" resolve the common prefix only once.
+// If a path can not be resolved, the error of the first such path is returned.
+func GetPaths(ptr interface{}, paths ...string) ([]interface{}, error) {
+	results := make([]interface{}, len(paths))
+	errs := make([]error, len(paths))
+
+	// build a tree of the path elements, where paths with a common prefix share the nodes
+	root := &pathNode{}
+	for i, path := range paths {
+		pathelements, err := parsePath(path)
+		if err != nil {
+			errs[i] = err
+			continue
+		}
+
+		node := root
+		for j := range pathelements {
+			node = node.child(pathelements[j : j+1])
+		}
+		node.indexes = append(node.indexes, i)
+	}
+
+	resolvePathNode(root, reflect.ValueOf(ptr), nil, results, errs)
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return results, nil
+}
+
+// resolvePathNode resolves the paths ending at the node and below it, starting from the value of the node.
+// An error resolving the node is passed to all paths below it.
+func resolvePathNode(node *pathNode, objValue reflect.Value, err error, results []interface{}, errs []error) {
+	for _, i := range node.indexes {
+		if err != nil {
+			errs[i] = err
+		} else {
+			results[i], errs[i] = getInterfaceOfValue(objValue)
+		}
+	}
+
+	for _, child := range node.children {
+		if err != nil {
+			resolvePathNode(child, reflect.Value{}, err, results, errs)
+			continue
+		}
+
+		// resolve a single path element, the remaining elements are resolved from there
+		elemValue, elemErr := getPathValue(objValue, child.pathelement)
+		resolvePathNode(child, elemValue, elemErr, results, errs)
+	}
+}
+
+// child returns the child node for the path element and creates it, if it does not exist yet
+func (n *pathNode) child(pathelement []string) *pathNode {
+	for _, child := range n.children {
+		if child.pathelement[0] == pathelement[0] {
+			return child
+		}
+	}
+
+	child := &pathNode{pathelement: pathelement}
+	n.children = append(n.children, child)
+	return child
+}
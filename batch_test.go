@@ -0,0 +1,133 @@
+package piranhas
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestGetPaths(t *testing.T) {
+	data := buildPersonData()
+
+	tests := []struct {
+		name  string
+		paths []string
+		err   error
+	}{
+		{
+			name:  "Paths sharing prefixes",
+			paths: []string{"address.street", "address.number", "address.city", "address.ZIP", "adresses1.0.street", "adresses1.1.street", "firstName"},
+			err:   nil,
+		},
+		{
+			name:  "Duplicate paths and different notations",
+			paths: []string{"hobbys.Motorcycle", "hobbys[Motorcycle]", "$.hobbys.Skydiving", "hobbys/Crochet"},
+			err:   nil,
+		},
+		{
+			name:  "Plain and bracket notations of the same prefix",
+			paths: []string{"adresses1.1.ZIP", "adresses1[1].ZIP", "adresses1.1.city", "adresses1/1/street"},
+			err:   nil,
+		},
+		{
+			name:  "Path and its prefix",
+			paths: []string{"lastName", "address.city", "passport.number", "number"},
+			err:   nil,
+		},
+		{
+			name:  "Failing path behind a valid one",
+			paths: []string{"address.city", "address.country", "address.city.foo"},
+			err:   errObjNotExists,
+		},
+		{
+			name:  "Space in element after the last dot",
+			paths: []string{"address.city", "address. city"},
+			err:   errSpaceInElement,
+		},
+		{
+			name:  "Path too long",
+			paths: []string{"age.value", "address.city"},
+			err:   errPathToLong,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := GetPaths(data, test.paths...)
+			if err != test.err {
+				t.Errorf("Expected error: %v, but got: %v", test.err, err)
+			}
+			if err != nil {
+				return
+			}
+
+			// the result has to be identical to resolving every path on its own
+			expected := make([]interface{}, len(test.paths))
+			for i, path := range test.paths {
+				expected[i], err = GetPathInterface(data, path)
+				if err != nil {
+					t.Fatalf("Unexpected error for path %s: %v", path, err)
+				}
+			}
+			if !reflect.DeepEqual(result, expected) {
+				t.Errorf("Expected %v, but got %v", expected, result)
+			}
+		})
+	}
+}
+
+type benchEndpoint struct {
+	host    string
+	port    int
+	tls     bool
+	timeout time.Duration
+}
+
+type benchService struct {
+	name      string
+	endpoints []benchEndpoint
+}
+
+type benchConfig struct {
+	cluster struct {
+		services map[string]*benchService
+	}
+}
+
+// buildBenchConfig returns a configuration, which is read field by field with long common prefixes
+func buildBenchConfig() (*benchConfig, []string) {
+	data := &benchConfig{}
+	data.cluster.services = map[string]*benchService{
+		"api":    {name: "api", endpoints: []benchEndpoint{{host: "api-0", port: 80}, {host: "api-1", port: 443, tls: true}}},
+		"worker": {name: "worker", endpoints: []benchEndpoint{{host: "worker-0", port: 9000, timeout: time.Minute}}},
+	}
+
+	paths := make([]string, 0)
+	for _, prefix := range []string{"cluster.services.api.endpoints.0", "cluster.services.api.endpoints.1", "cluster.services.worker.endpoints.0"} {
+		for _, field := range []string{"host", "port", "tls", "timeout"} {
+			paths = append(paths, prefix+"."+field)
+		}
+	}
+
+	return data, paths
+}
+
+func BenchmarkGetPathsNaive(b *testing.B) {
+	data, paths := buildBenchConfig()
+	for n := 0; n < b.N; n++ {
+		for _, path := range paths {
+			if _, err := GetPathInterface(data, path); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+func BenchmarkGetPaths(b *testing.B) {
+	data, paths := buildBenchConfig()
+	for n := 0; n < b.N; n++ {
+		if _, err := GetPaths(data, paths...); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
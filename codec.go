@@ -0,0 +1,69 @@
+package piranhas
+
+import (
+	"errors"
+	"sync"
+)
+
+var (
+	errUnknownCodec = errors.New("codec is not registered")
+)
+
+// codec holds the encoder and decoder registered under a name
+type codec struct {
+	enc func([]byte) ([]byte, error)
+	dec func([]byte) ([]byte, error)
+}
+
+var (
+	codecs      = make(map[string]codec)
+	codecsMutex sync.RWMutex
+)
+
+// RegisterCodec registers an encoder and a decoder with the name. A string or []byte field tagged with
+// `codec:"name"` is decoded by GetPathDecoded. A codec of the same name is replaced.
+func RegisterCodec(name string, enc, dec func([]byte) ([]byte, error)) {
+	codecsMutex.Lock()
+	defer codecsMutex.Unlock()
+	codecs[name] = codec{enc: enc, dec: dec}
+}
+
+// GetPathDecoded returns the string or []byte field addressed by the path decoded by the codec of its codec tag.
+// Fields without a codec tag are returned like by GetPathInterface.
+func GetPathDecoded(ptr interface{}, path string) (interface{}, error) {
+	field, fieldValue, err := getPathStructField(ptr, path)
+	if err != nil {
+		return nil, err
+	}
+
+	obj, err := getInterfaceOfValue(fieldValue)
+	if err != nil {
+		return nil, err
+	}
+
+	codecTag := field.Tag.Get("codec")
+	if codecTag == "" || obj == nil {
+		return obj, nil
+	}
+
+	codecsMutex.RLock()
+	c, ok := codecs[codecTag]
+	codecsMutex.RUnlock()
+	if !ok {
+		return nil, errUnknownCodec
+	}
+
+	switch value := obj.(type) {
+	case string:
+		decoded, err := c.dec([]byte(value))
+		if err != nil {
+			return nil, err
+		}
+		return string(decoded), nil
+
+	case []byte:
+		return c.dec(value)
+	}
+
+	return nil, errors.New("object is not a string or []byte")
+}
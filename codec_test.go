@@ -0,0 +1,92 @@
+package piranhas
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// rot13 rotates the latin letters by 13 places, so applying it twice returns the input
+func rot13(data []byte) ([]byte, error) {
+	result := make([]byte, len(data))
+	for i, b := range data {
+		switch {
+		case b >= 'a' && b <= 'z':
+			result[i] = 'a' + (b-'a'+13)%26
+		case b >= 'A' && b <= 'Z':
+			result[i] = 'A' + (b-'A'+13)%26
+		default:
+			result[i] = b
+		}
+	}
+	return result, nil
+}
+
+type codecCredentials struct {
+	user     string
+	password string  `codec:"piranhas-test-rot13"`
+	token    []byte  `codec:"piranhas-test-rot13"`
+	hint     *string `codec:"piranhas-test-rot13"`
+	pin      int     `codec:"piranhas-test-rot13"`
+	secret   string  `codec:"piranhas-test-failing"`
+	unknown  string  `codec:"piranhas-test-unknown"`
+}
+
+type codecAccount struct {
+	credentials codecCredentials
+	tokens      []string
+}
+
+func TestGetPathDecoded(t *testing.T) {
+	RegisterCodec("piranhas-test-rot13", rot13, rot13)
+	RegisterCodec("piranhas-test-failing", rot13, func([]byte) ([]byte, error) {
+		return nil, errors.New("decoding failed")
+	})
+
+	encoded, _ := rot13([]byte("s3cret Passw0rd"))
+	data := &codecAccount{
+		credentials: codecCredentials{
+			user:     "karl",
+			password: string(encoded),
+			token:    []byte("nop"),
+			pin:      1234,
+			secret:   "abc",
+			unknown:  "abc",
+		},
+		tokens: []string{"abc"},
+	}
+
+	tests := []struct {
+		name     string
+		path     string
+		expected interface{}
+		err      error
+	}{
+		{name: "Decoded string", path: "credentials.password", expected: "s3cret Passw0rd", err: nil},
+		{name: "Decoded []byte", path: "credentials.token", expected: []byte("abc"), err: nil},
+		{name: "Field without codec", path: "credentials.user", expected: "karl", err: nil},
+		{name: "Nil pointer", path: "credentials.hint", expected: nil, err: nil},
+		{name: "Field of another type", path: "credentials.pin", expected: nil, err: errors.New("object is not a string or []byte")},
+		{name: "Failing decoder", path: "credentials.secret", expected: nil, err: errors.New("decoding failed")},
+		{name: "Unknown codec", path: "credentials.unknown", expected: nil, err: errUnknownCodec},
+		{name: "Not a struct field", path: "tokens.0", expected: nil, err: errNoStructField},
+		{name: "Field does not exist", path: "credentials.email", expected: nil, err: errObjNotExists},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := GetPathDecoded(data, test.path)
+			if (err == nil) != (test.err == nil) || (err != nil && err.Error() != test.err.Error()) {
+				t.Errorf("Expected error: %v, but got: %v", test.err, err)
+			}
+			if !reflect.DeepEqual(result, test.expected) {
+				t.Errorf("Expected %v, but got %v", test.expected, result)
+			}
+		})
+	}
+
+	// the field itself stays encoded
+	if data.credentials.password != string(encoded) {
+		t.Errorf("Expected the field to stay encoded, but got %s", data.credentials.password)
+	}
+}
@@ -0,0 +1,175 @@
+package piranhas
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"unsafe"
+)
+
+var (
+	errInvalidOutput = errors.New("output must be a non-nil pointer")
+	errNotAssignable = errors.New("element is not assignable to the output")
+)
+
+// DecodePath resolves the subtree addressed by the path and copies it into out, which must be a non-nil pointer.
+// If the types differ, structs are copied field by field, where the fields are matched by name.
+// Slices, arrays and maps are copied element by element, fields missing in the subtree keep their value.
+func DecodePath(ptr interface{}, path string, out interface{}) error {
+	objValue, err := getPathReflectValue(ptr, path)
+	if err != nil {
+		return err
+	}
+	if !objValue.IsValid() {
+		return errObjNotExists
+	}
+
+	outValue := reflect.ValueOf(out)
+	if outValue.Kind() != reflect.Ptr || outValue.IsNil() {
+		return errInvalidOutput
+	}
+
+	return decodeValue(objValue, outValue.Elem())
+}
+
+// decodeValue copies src into the addressable dst, converting between structurally compatible types
+func decodeValue(src reflect.Value, dst reflect.Value) error {
+	// make unexported destinations settable
+	if !dst.CanSet() {
+		dst = reflect.NewAt(dst.Type(), unsafe.Pointer(dst.UnsafeAddr())).Elem()
+	}
+
+	// read all pointers and interfaces of the source away, nil resets the destination
+	for src.Kind() == reflect.Ptr || src.Kind() == reflect.Interface {
+		if src.IsNil() {
+			dst.Set(reflect.Zero(dst.Type()))
+			return nil
+		}
+		src = src.Elem()
+	}
+
+	// values of unexported fields have to be made readable first
+	src = getUnexportedValue(src)
+
+	// fields of structs and elements of arrays, which are not addressable like map values, can only be read through a copy
+	if !src.CanAddr() && (src.Kind() == reflect.Struct || src.Kind() == reflect.Array) && src.CanInterface() {
+		addrValue := reflect.New(src.Type()).Elem()
+		addrValue.Set(src)
+		src = addrValue
+	}
+
+	// same or assignable types are copied directly
+	if src.Type().AssignableTo(dst.Type()) {
+		if !src.CanInterface() {
+			return errIsNotInterfaceable
+		}
+		dst.Set(src)
+		return nil
+	}
+
+	switch dst.Kind() {
+	case reflect.Ptr:
+		// allocate the pointee and decode into it
+		elemValue := reflect.New(dst.Type().Elem())
+		if err := decodeValue(src, elemValue.Elem()); err != nil {
+			return err
+		}
+		dst.Set(elemValue)
+		return nil
+
+	case reflect.Struct:
+		if src.Kind() != reflect.Struct {
+			return errNotAssignable
+		}
+
+		// copy the fields with the same name
+		for i := 0; i < dst.NumField(); i++ {
+			field := dst.Type().Field(i)
+			srcField := src.FieldByName(field.Name)
+			if !srcField.IsValid() {
+				continue
+			}
+			if err := decodeValue(srcField, dst.Field(i)); err != nil {
+				return fmt.Errorf("failed to decode field %s: %s", field.Name, err)
+			}
+		}
+		return nil
+
+	case reflect.Slice:
+		if src.Kind() != reflect.Slice && src.Kind() != reflect.Array {
+			return errNotAssignable
+		}
+
+		// copy the elements into a new slice
+		sliceValue := reflect.MakeSlice(dst.Type(), src.Len(), src.Len())
+		for i := 0; i < src.Len(); i++ {
+			if err := decodeValue(src.Index(i), sliceValue.Index(i)); err != nil {
+				return err
+			}
+		}
+		dst.Set(sliceValue)
+		return nil
+
+	case reflect.Array:
+		if (src.Kind() != reflect.Slice && src.Kind() != reflect.Array) || src.Len() != dst.Len() {
+			return errNotAssignable
+		}
+
+		// copy the elements in place
+		for i := 0; i < src.Len(); i++ {
+			if err := decodeValue(src.Index(i), dst.Index(i)); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case reflect.Map:
+		if src.Kind() != reflect.Map || src.IsNil() {
+			if src.Kind() == reflect.Map {
+				dst.Set(reflect.Zero(dst.Type()))
+				return nil
+			}
+			return errNotAssignable
+		}
+
+		// copy the keys and values into a new map
+		mapValue := reflect.MakeMapWithSize(dst.Type(), src.Len())
+		for _, key := range src.MapKeys() {
+			keyValue := reflect.New(dst.Type().Key()).Elem()
+			if err := decodeValue(key, keyValue); err != nil {
+				return err
+			}
+			elemValue := reflect.New(dst.Type().Elem()).Elem()
+			if err := decodeValue(src.MapIndex(key), elemValue); err != nil {
+				return err
+			}
+			mapValue.SetMapIndex(keyValue, elemValue)
+		}
+		dst.Set(mapValue)
+		return nil
+	}
+
+	// scalar values of the same kind family like named types or int and int64 are converted
+	if kindFamily(src.Kind()) == kindFamily(dst.Kind()) && src.Type().ConvertibleTo(dst.Type()) && src.CanInterface() {
+		dst.Set(src.Convert(dst.Type()))
+		return nil
+	}
+
+	return errNotAssignable
+}
+
+// kindFamily groups the kinds, between which a conversion does not change the meaning of the value
+func kindFamily(kind reflect.Kind) reflect.Kind {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return reflect.Int
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return reflect.Uint
+	case reflect.Float32, reflect.Float64:
+		return reflect.Float64
+	case reflect.Complex64, reflect.Complex128:
+		return reflect.Complex128
+	}
+
+	return kind
+}
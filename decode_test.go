@@ -0,0 +1,135 @@
+package piranhas
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type location struct {
+	street string
+	number int64
+	city   string
+	ZIP    *string
+}
+
+type locationInvalid struct {
+	street []string
+}
+
+type locationHolder struct {
+	byName map[string]address
+	ByName map[string]address
+}
+
+func TestDecodePath(t *testing.T) {
+	data := buildPersonData()
+	zip := "10553"
+	zip0 := "10487"
+	zip1 := "10000"
+
+	tests := []struct {
+		name     string
+		path     string
+		out      interface{}
+		expected interface{}
+		err      error
+	}{
+		{
+			name:     "Struct into structurally compatible struct",
+			path:     "address",
+			out:      &location{},
+			expected: &location{street: "Tellerstraße", number: 29, city: "Berlin", ZIP: &zip},
+			err:      nil,
+		},
+		{
+			name: "Slice of structs into slice of compatible structs",
+			path: "adresses1",
+			out:  &[]location{},
+			expected: &[]location{
+				{street: "Müllerstr", number: 129, city: "Berlin", ZIP: &zip0},
+				{street: "Kanzlerpaltz", number: 1, city: "Berlin", ZIP: &zip1},
+			},
+			err: nil,
+		},
+		{
+			name:     "Map into map",
+			path:     "hobbys",
+			out:      &map[string]int64{},
+			expected: &map[string]int64{"Motorcycle": 10, "Skydiving": 9, "Crochet": 0},
+			err:      nil,
+		},
+		{
+			name:     "Same type",
+			path:     "address.city",
+			out:      new(string),
+			expected: &[]string{"Berlin"}[0],
+			err:      nil,
+		},
+		{
+			name:     "Incompatible field",
+			path:     "address",
+			out:      &locationInvalid{},
+			expected: &locationInvalid{},
+			err:      errors.New("failed to decode field street: element is not assignable to the output"),
+		},
+		{
+			name:     "Output is not a pointer",
+			path:     "address",
+			out:      location{},
+			expected: location{},
+			err:      errInvalidOutput,
+		},
+		{
+			name:     "Object does not exist",
+			path:     "passport.country",
+			out:      &location{},
+			expected: &location{},
+			err:      errObjNotExists,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := DecodePath(data, test.path, test.out)
+			if (err == nil) != (test.err == nil) || (err != nil && err.Error() != test.err.Error()) {
+				t.Errorf("Expected error: %v, but got: %v", test.err, err)
+			}
+			if !reflect.DeepEqual(test.out, test.expected) {
+				t.Errorf("Expected %+v, but got %+v", test.expected, test.out)
+			}
+		})
+	}
+}
+
+func TestDecodePathMapValue(t *testing.T) {
+	data := &locationHolder{
+		byName: map[string]address{"home": {street: "Tellerstraße", number: 29, city: "Berlin", ZIP: "10553"}},
+		ByName: map[string]address{"home": {street: "Tellerstraße", number: 29, city: "Berlin", ZIP: "10553"}},
+	}
+	zip := "10553"
+	home := location{street: "Tellerstraße", number: 29, city: "Berlin", ZIP: &zip}
+
+	tests := []struct {
+		name     string
+		path     string
+		out      interface{}
+		expected interface{}
+	}{
+		{name: "Map of structs", path: "byName", out: &map[string]location{}, expected: &map[string]location{"home": home}},
+		{name: "Struct of a map", path: "byName.home", out: &location{}, expected: &home},
+		{name: "Struct of an exported map", path: "ByName.home", out: &location{}, expected: &home},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := DecodePath(data, test.path, test.out)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(test.out, test.expected) {
+				t.Errorf("Expected %+v, but got %+v", test.expected, test.out)
+			}
+		})
+	}
+}
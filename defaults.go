@@ -4,18 +4,238 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net"
+	"net/url"
+	"os"
 	"reflect"
 	"strconv"
 	"strings"
+	"text/template"
 	"time"
 )
 
 var (
-	errSyntax = errors.New("invalid syntax")
+	errSyntax    = errors.New("invalid syntax")
+	errNotInEnum = errors.New("value is not a member of the enum")
+	errTrimType  = errors.New("trim tag requires a string field")
 )
 
+// AfterDefaulter is implemented by structs, which derive some of their fields from the others.
+// AfterDefaults is called after the defaults of all fields of the struct are set, an error aborts setting the defaults.
+// Like every method it is called through the method set of the outermost struct, so a method promoted from an embedded
+// struct is called once, and a method declared by the embedding struct replaces the one of the embedded struct.
+type AfterDefaulter interface {
+	AfterDefaults() error
+}
+
+// afterDefaulterType is the type of the AfterDefaulter interface
+var afterDefaulterType = reflect.TypeOf((*AfterDefaulter)(nil)).Elem()
+
+// defaultOptions controls the optional behavior while setting the defaults
+type defaultOptions struct {
+	// templates enables rendering string defaults containing "{{" with text/template
+	templates bool
+
+	// when decides for every scalar field with a default tag, if the default is applied
+	when func(field reflect.StructField, current reflect.Value) bool
+
+	// env enables reading the environment variable named by the env tag in place of the default tag
+	env bool
+
+	// envDerived reads the environment variables named like by EnvKeysForDefaults with envPrefix in front of them
+	envDerived bool
+	envPrefix  string
+
+	// envNames holds the names of the struct fields passed through from the root in UPPER_SNAKE notation,
+	// it is nil below slices and maps, where no names are derived
+	envNames []string
+
+	// result collects the outcome of the fields, errors of single fields do not stop setting the other defaults then
+	result *Result
+
+	// path holds the path elements of the struct, slice or map currently passed through, if a result is collected
+	path []string
+
+	// exportedOnly skips unexported fields, so all fields are set by plain reflection without unsafe
+	exportedOnly bool
+
+	// visiting holds the structs currently passed through, so cyclic pointers are not followed endlessly
+	visiting map[visit]bool
+
+	// hookedByOuter skips AfterDefaults of the embedded struct passed through, because the embedding struct calls it
+	hookedByOuter bool
+}
+
+// set overwrites the field with the value, unexported fields are written through their memory
+func (opts *defaultOptions) set(fieldValue reflect.Value, value reflect.Value) {
+	if opts.exportedOnly {
+		fieldValue.Set(value)
+	} else {
+		setUnexportedField(fieldValue, value)
+	}
+}
+
+// ptrTo returns a pointer to the value or the pointer itself, if the value is a pointer
+func (opts *defaultOptions) ptrTo(v reflect.Value) interface{} {
+	if !opts.exportedOnly {
+		return getPtrInterface(v)
+	}
+
+	if v.Kind() == reflect.Ptr {
+		return v.Interface()
+	}
+	return v.Addr().Interface()
+}
+
+// at returns the options for passing through the element with the path element below the current path
+func (opts *defaultOptions) at(pathelement string) *defaultOptions {
+	if opts.result == nil && opts.envNames == nil {
+		return opts
+	}
+
+	child := *opts
+	if opts.result != nil {
+		child.path = append(opts.path[:len(opts.path):len(opts.path)], pathelement)
+	}
+	child.envNames = nil
+	return &child
+}
+
+// atField returns the options for passing through the struct field with the name like at,
+// where the name is added to the names of the environment variables too
+func (opts *defaultOptions) atField(name string) *defaultOptions {
+	child := opts.at(name)
+	if opts.envNames != nil {
+		child.envNames = append(opts.envNames[:len(opts.envNames):len(opts.envNames)], toUpperSnake(name))
+	}
+	return child
+}
+
+// envName returns the name of the environment variable, which is read for the field, or "" if there is none
+func (opts *defaultOptions) envName(field reflect.StructField) string {
+	if !opts.env {
+		return ""
+	}
+	envTag := field.Tag.Get("env")
+	if !opts.envDerived {
+		return envTag
+	}
+
+	// the names are derived like by EnvKeysForDefaults
+	if opts.envNames == nil || field.Name == "_" {
+		return ""
+	}
+	if envTag != "" {
+		return opts.envPrefix + envTag
+	}
+	if _, ok := field.Tag.Lookup("default"); ok {
+		return opts.envPrefix + strings.Join(append(opts.envNames[:len(opts.envNames):len(opts.envNames)], toUpperSnake(field.Name)), "_")
+	}
+	return ""
+}
+
+// fieldPath returns the dotted path of the field in the current struct, an empty name stands for the struct itself
+func (opts *defaultOptions) fieldPath(name string) string {
+	if name == "" {
+		return strings.Join(opts.path, ".")
+	}
+	return strings.Join(append(opts.path[:len(opts.path):len(opts.path)], name), ".")
+}
+
+// setField overwrites the field with the default value and records, whether this changed the field
+func (opts *defaultOptions) setField(name string, fieldValue reflect.Value, defaultValue reflect.Value) {
+	if opts.result != nil {
+		action := ActionApplied
+		if reflect.DeepEqual(getUnexportedValue(fieldValue).Interface(), defaultValue.Interface()) {
+			action = ActionSkipped
+		}
+		opts.result.add(FieldOutcome{Path: opts.fieldPath(name), Action: action})
+	}
+
+	opts.set(fieldValue, defaultValue)
+}
+
+// skipField records a field, whose default is not applied
+func (opts *defaultOptions) skipField(name string) {
+	if opts.result != nil {
+		opts.result.add(FieldOutcome{Path: opts.fieldPath(name), Action: ActionSkipped})
+	}
+}
+
+// fail returns the error of the field, or records it and returns nil, if a result is collected
+func (opts *defaultOptions) fail(name string, err error) error {
+	if opts.result == nil {
+		return err
+	}
+
+	opts.result.add(FieldOutcome{Path: opts.fieldPath(name), Action: ActionFailed, Err: err})
+	return nil
+}
+
 // SetDefaults sets default values for fields in a struct, elements in a slice, or values in a map, based on the type of the provided pointer
 func SetDefaults(ptr interface{}) (err error) {
+	return setDefaults(ptr, &defaultOptions{})
+}
+
+// SetDefaultsTemplate sets default values like SetDefaults, but string defaults containing "{{" are rendered with text/template.
+// The fields of the struct are the template context, so `default:"{{.host}}:{{.port}}"` combines two sibling fields.
+// Templates are rendered after all other defaults of the struct are set.
+func SetDefaultsTemplate(ptr interface{}) error {
+	return setDefaults(ptr, &defaultOptions{templates: true})
+}
+
+// SetDefaultsWhen sets default values like SetDefaults, but for every scalar field with a default tag
+// the predicate is called with the field and its current value, and the default is only applied if it returns true.
+func SetDefaultsWhen(ptr interface{}, pred func(field reflect.StructField, current reflect.Value) bool) error {
+	return setDefaults(ptr, &defaultOptions{when: pred})
+}
+
+// SetDefaultsTx sets default values like SetDefaults, but all or nothing.
+// The value behind the pointer is copied deeply before, and if an error occurs the copy is written back into the
+// original memory, so that no partially set defaults remain. Pointers, slices and maps below it keep their identity,
+// so values sharing them with the struct are restored too.
+func SetDefaultsTx(ptr interface{}) error {
+	v := reflect.ValueOf(ptr)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return setDefaults(ptr, &defaultOptions{})
+	}
+
+	// take a snapshot of the original
+	snapshot := reflect.New(v.Elem().Type()).Elem()
+	originals := snapshotValue(snapshot, v.Elem())
+
+	err := setDefaults(ptr, &defaultOptions{})
+	if err != nil {
+		// roll back the defaults set so far
+		restoreValue(v.Elem(), snapshot, originals)
+	}
+
+	return err
+}
+
+// SetDefaultsExportedOnly sets default values like SetDefaults, but unexported fields are skipped entirely.
+// The exported fields are set by plain reflection, so no memory is written through unsafe, and profiles are not applied.
+func SetDefaultsExportedOnly(ptr interface{}) error {
+	return setDefaults(ptr, &defaultOptions{exportedOnly: true})
+}
+
+// SetDefaultsEnv sets default values like SetDefaults, but fields with an env tag like `env:"DATABASE_URL"`
+// get the value of this environment variable, which is parsed like a default tag.
+// If the environment variable is not set, the default tag is used.
+func SetDefaultsEnv(ptr interface{}) error {
+	return setDefaults(ptr, &defaultOptions{env: true})
+}
+
+// SetDefaultsEnvPrefix sets default values like SetDefaultsEnv, but reads the environment variables listed by
+// EnvKeysForDefaults with the same prefix. So the prefix is put in front of the env tags, and fields with a default
+// tag but without env tag read the name derived from their path like APP_DATABASE_PORT.
+// Elements of slices and maps as well as fields below pointers to structs have no such names and get their default tags only.
+func SetDefaultsEnvPrefix(ptr interface{}, prefix string) error {
+	return setDefaults(ptr, &defaultOptions{env: true, envDerived: true, envPrefix: envKeyPrefix(prefix), envNames: []string{}})
+}
+
+// setDefaults sets default values based on the type of the provided pointer with the given options
+func setDefaults(ptr interface{}, opts *defaultOptions) (err error) {
 	// obtain the reflect.Value of the provided pointer
 	v := reflect.ValueOf(ptr)
 	// check if the provided value is a pointer
@@ -23,6 +243,10 @@ func SetDefaults(ptr interface{}) (err error) {
 		return
 	}
 
+	if opts.visiting == nil {
+		opts.visiting = make(map[visit]bool)
+	}
+
 	// determine the type of the object
 	objType := v.Type()
 	for objType.Kind() == reflect.Ptr {
@@ -33,20 +257,29 @@ func SetDefaults(ptr interface{}) (err error) {
 	switch objType.Kind() {
 	case reflect.Struct:
 		// set defaults for struct fields
-		err = setDefaultsStruct(ptr)
+		err = setDefaultsStruct(ptr, opts)
 	case reflect.Slice, reflect.Array:
 		// set defaults for slice and array elements
-		err = setDefaultsSlice(ptr)
+		err = setDefaultsSlice(ptr, opts)
 	case reflect.Map:
 		// set defaults for map values
-		err = setDefaultsMap(ptr)
+		err = setDefaultsMap(ptr, opts)
 	}
 
 	return err
 }
 
+// fieldDeferral tells whether the default of a field is evaluated after all other fields of the struct are set
+type fieldDeferral int
+
+const (
+	deferNone fieldDeferral = iota
+	deferComputed
+	deferTemplate
+)
+
 // setDefaultsStruct sets default values for elements in a struct
-func setDefaultsStruct(ptr interface{}) (err error) {
+func setDefaultsStruct(ptr interface{}, opts *defaultOptions) (err error) {
 	// read all pointers away
 	objValue := reflect.ValueOf(ptr)
 	for {
@@ -67,82 +300,470 @@ func setDefaultsStruct(ptr interface{}) (err error) {
 		return nil
 	}
 
+	// the hook of an embedded struct is in the method set of the embedding struct, its fields are not affected
+	hookedByOuter := opts.hookedByOuter
+	if hookedByOuter {
+		child := *opts
+		child.hookedByOuter = false
+		opts = &child
+	}
+
+	// a struct, which is passed through further up, is reached again through a cycle of pointers
+	if objValue.CanAddr() {
+		v := visit{objValue.UnsafeAddr(), objType}
+		if opts.visiting[v] {
+			return nil
+		}
+		opts.visiting[v] = true
+		defer delete(opts.visiting, v)
+	}
+
+	// a blank field with a defaults tag seeds the struct from a profile before the default tags are applied
+	for i := 0; i < objType.NumField(); i++ {
+		if profileTag := objType.Field(i).Tag.Get("defaults"); objType.Field(i).Name == "_" && profileTag != "" && !opts.exportedOnly {
+			if err := applyProfile(objValue, profileTag); err != nil {
+				if err := opts.fail(objType.Field(i).Name, fmt.Errorf("failed to apply defaults profile %s: %s", profileTag, err)); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	// computed and template defaults are evaluated after all other fields of the struct are set
+	computed := make([]int, 0)
+	templated := make([]int, 0)
+
 	// iterate over all fields of the struct
 	for i := 0; i < objType.NumField(); i++ {
-		// Get field and its value
+		deferral, err := setDefaultsField(objValue, i, opts)
+		if err != nil {
+			if err := opts.fail(objType.Field(i).Name, err); err != nil {
+				return err
+			}
+			continue
+		}
+
+		switch deferral {
+		case deferComputed:
+			computed = append(computed, i)
+		case deferTemplate:
+			templated = append(templated, i)
+		}
+	}
+
+	// evaluate the computed defaults against the already set sibling fields
+	for _, i := range computed {
 		field := objType.Field(i)
 		fieldValue := objValue.Field(i)
-		defaultTag := field.Tag.Get("default")
-		layoutTag := field.Tag.Get("layout")
 
-		// determine the type of the field element
-		fieldValueType := fieldValue.Type()
-		for fieldValueType.Kind() == reflect.Ptr {
-			fieldValueType = fieldValueType.Elem()
+		defaultValue, err := parseComputedDefault(field.Tag.Get("default"), objValue, fieldValue.Type())
+		if err != nil {
+			if err := opts.fail(field.Name, fmt.Errorf("failed to parse default tag for field %s: %s", field.Name, err)); err != nil {
+				return err
+			}
+			continue
 		}
 
-		// set or call recursively based on field type
-		switch fieldValueType.Kind() {
-		case reflect.Invalid:
-			// do nothing for invalid type
-		case reflect.Struct:
-			if fieldValue.Type().String() == "time.Time" && defaultTag != "" {
-				defaultValue, err := parseDefaultValue(defaultTag, layoutTag, fieldValue.Type())
-				if err != nil {
-					return fmt.Errorf("failed to parse default tag for field %s: %s", field.Name, err)
-				}
+		// overwrite the value with the computed value
+		opts.setField(field.Name, fieldValue, defaultValue)
+	}
 
-				// overwrite the value with the default value
-				setUnexportedField(fieldValue, defaultValue)
-			} else {
-				err = setDefaultsStruct(getPtrInterface(fieldValue))
+	// render the template defaults with the completely set struct
+	for _, i := range templated {
+		field := objType.Field(i)
+		fieldValue := objValue.Field(i)
+
+		defaultValue, err := parseTemplateDefault(field.Tag.Get("default"), objValue, fieldValue.Type())
+		if err != nil {
+			if err := opts.fail(field.Name, fmt.Errorf("failed to parse default tag for field %s: %s", field.Name, err)); err != nil {
+				return err
 			}
+			continue
+		}
 
-		case reflect.Slice, reflect.Array:
-			if defaultTag != "" && defaultTag != "[]" {
-				defaultValue, err := parseDefaultValue(defaultTag, layoutTag, fieldValue.Type())
-				if err != nil {
-					return fmt.Errorf("failed to parse default tag for field %s: %s", field.Name, err)
-				}
+		// overwrite the value with the rendered value
+		opts.setField(field.Name, fieldValue, defaultValue)
+	}
+
+	// normalize the string fields with a trim tag, after all defaults of the struct are set
+	for i := 0; i < objType.NumField(); i++ {
+		field := objType.Field(i)
+		trimTag := field.Tag.Get("trim")
+		if trimTag == "" || (opts.exportedOnly && !field.IsExported()) {
+			continue
+		}
+
+		if err := trimField(objValue.Field(i), trimTag, opts); err != nil {
+			if err := opts.fail(field.Name, fmt.Errorf("failed to parse trim tag for field %s: %s", field.Name, err)); err != nil {
+				return err
+			}
+		}
+	}
+
+	// the struct finalizes its derived state, after all of its fields are populated
+	if hook, ok := opts.ptrTo(objValue).(AfterDefaulter); ok && !hookedByOuter {
+		if err := hook.AfterDefaults(); err != nil {
+			return opts.fail("", fmt.Errorf("failed to finish defaults of %s: %w", objType, err))
+		}
+	}
+
+	return nil
+}
+
+// trimField normalizes the value of a string field by the comma separated normalizations of the trim tag
+func trimField(fieldValue reflect.Value, trimTag string, opts *defaultOptions) error {
+	// read all pointers away, nil pointers have nothing to normalize
+	for fieldValue.Kind() == reflect.Ptr {
+		if fieldValue.IsNil() {
+			return nil
+		}
+		fieldValue = fieldValue.Elem()
+	}
+	if fieldValue.Kind() != reflect.String {
+		return errTrimType
+	}
+
+	normalized, err := normalizeString(fieldValue.String(), trimTag)
+	if err != nil {
+		return err
+	}
+	opts.set(fieldValue, reflect.ValueOf(normalized).Convert(fieldValue.Type()))
 
-				// overwrite the value with the default value
-				setUnexportedField(fieldValue, defaultValue)
+	return nil
+}
+
+// setDefaultsField sets the default value of the i-th field of the struct or passes through it recursively.
+// Computed and template defaults are not set, but reported to be evaluated after the other fields.
+func setDefaultsField(objValue reflect.Value, i int, opts *defaultOptions) (fieldDeferral, error) {
+	// Get field and its value
+	field := objValue.Type().Field(i)
+	fieldValue := objValue.Field(i)
+	if opts.exportedOnly && !field.IsExported() {
+		return deferNone, nil
+	}
+	defaultTag := field.Tag.Get("default")
+	layoutTag := field.Tag.Get("layout")
+
+	// the environment variable of the env tag takes precedence over the default tag
+	fromEnv := false
+	if envName := opts.envName(field); envName != "" {
+		if envValue, ok := os.LookupEnv(envName); ok {
+			defaultTag, fromEnv = envValue, true
+		}
+	}
+
+	// determine the type of the field element
+	fieldValueType := fieldValue.Type()
+	for fieldValueType.Kind() == reflect.Ptr {
+		fieldValueType = fieldValueType.Elem()
+	}
+
+	// set or call recursively based on field type
+	switch fieldValueType.Kind() {
+	case reflect.Invalid:
+		// do nothing for invalid type
+	case reflect.Struct:
+		if (fieldValueType.String() == "time.Time" || fieldValueType.String() == "url.URL") && defaultTag != "" {
+			var defaultValue reflect.Value
+			var err error
+			if _, ok := periodMacros[defaultTag]; ok && fieldValueType.String() == "time.Time" {
+				// the period is determined in the time zone of the tz tag
+				defaultValue, err = parsePeriodDefault(defaultTag, field.Tag.Get("tz"), fieldValue.Type())
 			} else {
-				err = setDefaultsSlice(getPtrInterface(fieldValue))
+				defaultValue, err = parseDefaultValue(defaultTag, layoutTag, fieldValue.Type())
+			}
+			if err != nil {
+				return deferNone, fmt.Errorf("failed to parse default tag for field %s: %s", field.Name, err)
 			}
 
-		case reflect.Map:
-			if defaultTag != "" && defaultTag != "{}" {
-				defaultValue, err := parseDefaultValue(defaultTag, layoutTag, fieldValue.Type())
-				if err != nil {
-					return fmt.Errorf("failed to parse default tag for field %s: %s", field.Name, err)
+			// overwrite the value with the default value
+			opts.setField(field.Name, fieldValue, defaultValue)
+		} else if !isNilPtr(fieldValue) && field.Name != "_" {
+			// a defaults tag on the field seeds the struct from a profile, which writes unexported fields too
+			if profileTag := field.Tag.Get("defaults"); profileTag != "" && !opts.exportedOnly {
+				structValue := fieldValue
+				for structValue.Kind() == reflect.Ptr {
+					structValue = structValue.Elem()
+				}
+				if err := applyProfile(structValue, profileTag); err != nil {
+					return deferNone, fmt.Errorf("failed to apply defaults profile %s for field %s: %s", profileTag, field.Name, err)
 				}
+			}
+
+			// fields below pointers have no derived names, like they are not listed by EnvKeysForDefaults
+			fieldOpts := opts.atField(field.Name)
+			if fieldValue.Kind() == reflect.Ptr {
+				fieldOpts = opts.at(field.Name)
+			}
+
+			// the embedding struct calls the hook of the embedded struct through its method set
+			if field.Anonymous && reflect.PtrTo(objValue.Type()).Implements(afterDefaulterType) {
+				child := *fieldOpts
+				child.hookedByOuter = true
+				fieldOpts = &child
+			}
+
+			return deferNone, setDefaultsStruct(opts.ptrTo(fieldValue), fieldOpts)
+		}
 
-				// overwrite the value with the default value
-				setUnexportedField(fieldValue, defaultValue)
+	case reflect.Slice, reflect.Array:
+		if defaultTag != "" && defaultTag != "[]" {
+			var defaultValue reflect.Value
+			var err error
+			if isStructSlice(fieldValueType) {
+				// the elements get the defaults of their own tags for the fields missing in the JSON
+				defaultValue, err = parseStructSliceDefault(defaultTag, fieldValue.Type(), opts.at(field.Name))
 			} else {
-				err = setDefaultsMap(getPtrInterface(fieldValue))
+				defaultValue, err = parseDefaultValue(defaultTag, layoutTag, fieldValue.Type())
+			}
+			if err != nil {
+				return deferNone, fmt.Errorf("failed to parse default tag for field %s: %s", field.Name, err)
 			}
 
-		default:
-			// handle scalar data types
-			if defaultTag != "" {
-				defaultValue, err := parseDefaultValue(defaultTag, layoutTag, fieldValue.Type())
+			// overwrite the value with the default value
+			opts.setField(field.Name, fieldValue, defaultValue)
+		} else if !isNilPtr(fieldValue) {
+			// nil pointer elements are allocated first with the alloc tag, so they get their defaults too
+			if field.Tag.Get("alloc") == "true" {
+				allocNilElements(fieldValue, opts)
+			}
+			return deferNone, setDefaultsSlice(opts.ptrTo(fieldValue), opts.at(field.Name))
+		}
+
+	case reflect.Map:
+		if defaultTag != "" && defaultTag != "{}" {
+			defaultValue, err := parseDefaultValue(defaultTag, layoutTag, fieldValue.Type())
+			if err != nil {
+				return deferNone, fmt.Errorf("failed to parse default tag for field %s: %s", field.Name, err)
+			}
+
+			// overwrite the value with the default value
+			opts.setField(field.Name, fieldValue, defaultValue)
+		} else if !isNilPtr(fieldValue) {
+			if err := setDefaultsMap(opts.ptrTo(fieldValue), opts.at(field.Name)); err != nil {
+				return deferNone, err
+			}
+		}
+
+		// seed the keys of the mapdefault tag, which are not present yet
+		if mapDefaultTag := field.Tag.Get("mapdefault"); mapDefaultTag != "" {
+			if err := seedMapDefaults(fieldValue, mapDefaultTag, opts); err != nil {
+				return deferNone, fmt.Errorf("failed to parse mapdefault tag for field %s: %s", field.Name, err)
+			}
+		}
+
+	default:
+		// handle scalar data types, the predicate can decide to keep the current value
+		if defaultTag != "" && opts.when != nil && !opts.when(field, getUnexportedValue(fieldValue)) {
+			opts.skipField(field.Name)
+			return deferNone, nil
+		}
+
+		// values of environment variables are never computed or rendered
+		if !fromEnv && isComputedDefault(defaultTag, fieldValueType) {
+			return deferComputed, nil
+		} else if !fromEnv && opts.templates && fieldValueType.Kind() == reflect.String && strings.Contains(defaultTag, "{{") {
+			return deferTemplate, nil
+		} else if defaultTag != "" {
+			// the default has to be one of the values of the enum tag
+			if enumTag := field.Tag.Get("enum"); enumTag != "" && !isEnumMember(defaultTag, enumTag) {
+				return deferNone, fmt.Errorf("failed to parse default tag for field %s: %s", field.Name, errNotInEnum)
+			}
+
+			// floating-point numbers can be written in the notation of a locale
+			if localeTag := field.Tag.Get("locale"); localeTag != "" && (fieldValueType.Kind() == reflect.Float32 || fieldValueType.Kind() == reflect.Float64) {
+				var err error
+				defaultTag, err = normalizeDecimal(defaultTag, localeTag)
 				if err != nil {
-					return fmt.Errorf("failed to parse default tag for field %s: %s", field.Name, err)
+					return deferNone, fmt.Errorf("failed to parse default tag for field %s: %s", field.Name, err)
 				}
+			}
 
-				// overwrite the value with the default value
-				setUnexportedField(fieldValue, defaultValue)
+			defaultValue, err := parseDefaultValue(defaultTag, layoutTag, fieldValue.Type())
+			if err != nil {
+				return deferNone, fmt.Errorf("failed to parse default tag for field %s: %s", field.Name, err)
 			}
+
+			// overwrite the value with the default value
+			opts.setField(field.Name, fieldValue, defaultValue)
 		}
 	}
 
-	return
+	return deferNone, nil
+}
+
+// isStructSlice reports whether the type is a slice or array of structs or pointers to structs
+func isStructSlice(sliceType reflect.Type) bool {
+	elemType := sliceType.Elem()
+	for elemType.Kind() == reflect.Ptr {
+		elemType = elemType.Elem()
+	}
+
+	return elemType.Kind() == reflect.Struct && elemType.String() != "time.Time"
+}
+
+// parseStructSliceDefault decodes a JSON array default into a slice or array of structs.
+// Every element gets the defaults of its own tags first and the JSON object is decoded over it,
+// so the fields missing in the JSON keep their defaults.
+func parseStructSliceDefault(defaultTag string, fieldType reflect.Type, opts *defaultOptions) (reflect.Value, error) {
+	// if the field type is a pointer, process the pointed-to type recursively and allocate a new pointee
+	if fieldType.Kind() == reflect.Ptr {
+		defaultValue, err := parseStructSliceDefault(defaultTag, fieldType.Elem(), opts)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		ptrValue := reflect.New(fieldType.Elem())
+		ptrValue.Elem().Set(defaultValue)
+		return ptrValue, nil
+	}
+
+	var elements []json.RawMessage
+	if err := json.Unmarshal([]byte(defaultTag), &elements); err != nil {
+		return reflect.Value{}, err
+	}
+
+	var defaultValue reflect.Value
+	if fieldType.Kind() == reflect.Array {
+		// like encoding/json, additional elements are ignored for arrays
+		defaultValue = reflect.New(fieldType).Elem()
+		if len(elements) > fieldType.Len() {
+			elements = elements[:fieldType.Len()]
+		}
+	} else {
+		defaultValue = reflect.MakeSlice(fieldType, len(elements), len(elements))
+	}
+
+	for i, element := range elements {
+		// null keeps the zero value of the element
+		if string(element) == "null" {
+			continue
+		}
+
+		elemType := fieldType.Elem()
+		isPtr := elemType.Kind() == reflect.Ptr
+		if isPtr {
+			elemType = elemType.Elem()
+		}
+
+		elemValue := reflect.New(elemType)
+		if err := setDefaults(elemValue.Interface(), opts.at(strconv.Itoa(i))); err != nil {
+			return reflect.Value{}, err
+		}
+		if err := json.Unmarshal(element, elemValue.Interface()); err != nil {
+			return reflect.Value{}, err
+		}
+
+		if isPtr {
+			defaultValue.Index(i).Set(elemValue)
+		} else {
+			defaultValue.Index(i).Set(elemValue.Elem())
+		}
+	}
+
+	return defaultValue, nil
+}
+
+// isEnumMember reports whether the value is one of the comma separated values of the enum tag
+func isEnumMember(value string, enumTag string) bool {
+	for _, member := range strings.Split(enumTag, ",") {
+		if strings.TrimSpace(member) == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+// parseTemplateDefault renders the default tag with text/template and converts it to a value of the field type.
+// The template context is a map of all fields of the struct, so also unexported fields can be referenced.
+func parseTemplateDefault(defaultTag string, objValue reflect.Value, fieldType reflect.Type) (reflect.Value, error) {
+	tmpl, err := template.New("default").Option("missingkey=error").Parse(defaultTag)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+
+	// collect the current values of all fields
+	context := make(map[string]interface{}, objValue.NumField())
+	for i := 0; i < objValue.NumField(); i++ {
+		value, err := getInterfaceOfValue(getUnexportedValue(objValue.Field(i)))
+		if err == nil {
+			context[objValue.Type().Field(i).Name] = value
+		}
+	}
+
+	var rendered strings.Builder
+	if err := tmpl.Execute(&rendered, context); err != nil {
+		return reflect.Value{}, err
+	}
+
+	return parseDefaultValue(rendered.String(), "", fieldType)
+}
+
+// isNilPtr reports whether the value is a nil pointer, which can not be passed through recursively
+func isNilPtr(v reflect.Value) bool {
+	return v.Kind() == reflect.Ptr && v.IsNil()
+}
+
+// isComputedDefault reports whether the default tag is an expression like "=width*2" for a numeric field
+func isComputedDefault(defaultTag string, fieldType reflect.Type) bool {
+	if !strings.HasPrefix(defaultTag, "=") {
+		return false
+	}
+
+	switch fieldType.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return fieldType.String() != "time.Duration"
+	}
+
+	return false
+}
+
+// parseComputedDefault evaluates the expression of the default tag, where field references are resolved
+// against the numeric sibling fields in objValue, and converts the result to a value of the field type
+func parseComputedDefault(defaultTag string, objValue reflect.Value, fieldType reflect.Type) (reflect.Value, error) {
+	result, err := evalExpression(strings.TrimPrefix(defaultTag, "="), func(name string) (float64, error) {
+		field, ok := objValue.Type().FieldByName(name)
+		if !ok {
+			return 0, errUnknownReference
+		}
+
+		// a field promoted through a nil embedded pointer can not be read
+		fieldValue, err := objValue.FieldByIndexErr(field.Index)
+		if err != nil {
+			return 0, errNoNumericField
+		}
+
+		// read all pointers away
+		for fieldValue.Kind() == reflect.Ptr {
+			if fieldValue.IsNil() {
+				return 0, errNoNumericField
+			}
+			fieldValue = fieldValue.Elem()
+		}
+
+		switch fieldValue.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return float64(fieldValue.Int()), nil
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			return float64(fieldValue.Uint()), nil
+		case reflect.Float32, reflect.Float64:
+			return fieldValue.Float(), nil
+		default:
+			return 0, errNoNumericField
+		}
+	})
+	if err != nil {
+		return reflect.Value{}, err
+	}
+
+	// integer fields only accept integral results, this is checked by the regular parser
+	return parseDefaultValue(strconv.FormatFloat(result, 'f', -1, 64), "", fieldType)
 }
 
 // setDefaultsSlice sets default values for elements in a slice or array
-func setDefaultsSlice(ptr interface{}) (err error) {
+func setDefaultsSlice(ptr interface{}, opts *defaultOptions) (err error) {
 	// read all pointers away
 	objValue := reflect.ValueOf(ptr)
 	for {
@@ -173,6 +794,11 @@ func setDefaultsSlice(ptr interface{}) (err error) {
 	for i := 0; i < objValue.Len(); i++ {
 		elemValue := objValue.Index(i)
 
+		// nil pointer elements have nothing to set
+		if isNilPtr(elemValue) {
+			continue
+		}
+
 		// determine the type of the slice or array element
 		elemValueType := elemValue.Type()
 		for elemValueType.Kind() == reflect.Ptr {
@@ -183,13 +809,13 @@ func setDefaultsSlice(ptr interface{}) (err error) {
 		switch elemValueType.Kind() {
 		case reflect.Struct:
 			// recursively set defaults for struct elements
-			err = setDefaultsStruct(getPtrInterface(elemValue))
+			err = setDefaultsStruct(opts.ptrTo(elemValue), opts.at(strconv.Itoa(i)))
 		case reflect.Slice, reflect.Array:
 			// recursively set defaults for slice or array elements
-			err = setDefaultsSlice(getPtrInterface(elemValue))
+			err = setDefaultsSlice(opts.ptrTo(elemValue), opts.at(strconv.Itoa(i)))
 		case reflect.Map:
 			// recursively set defaults for map elements
-			err = setDefaultsMap(getPtrInterface(elemValue))
+			err = setDefaultsMap(opts.ptrTo(elemValue), opts.at(strconv.Itoa(i)))
 		}
 
 		// if an error occurs during setting defaults, return the error
@@ -201,8 +827,21 @@ func setDefaultsSlice(ptr interface{}) (err error) {
 	return
 }
 
+// allocNilElements allocates a new zero value for every nil pointer element of the slice or array
+func allocNilElements(fieldValue reflect.Value, opts *defaultOptions) {
+	for fieldValue.Kind() == reflect.Ptr {
+		fieldValue = fieldValue.Elem()
+	}
+
+	for i := 0; i < fieldValue.Len(); i++ {
+		if elemValue := fieldValue.Index(i); isNilPtr(elemValue) {
+			opts.set(elemValue, reflect.New(elemValue.Type().Elem()))
+		}
+	}
+}
+
 // setDefaultsMap sets default values for elements in a map
-func setDefaultsMap(ptr interface{}) (err error) {
+func setDefaultsMap(ptr interface{}, opts *defaultOptions) (err error) {
 	// read all pointers away
 	objValue := reflect.ValueOf(ptr)
 	for {
@@ -226,6 +865,11 @@ func setDefaultsMap(ptr interface{}) (err error) {
 	// iterate through keys of the map
 	for _, key := range objValue.MapKeys() {
 		elemValue := objValue.MapIndex(key)
+
+		// nil pointer elements have nothing to set
+		if isNilPtr(elemValue) {
+			continue
+		}
 		elemPtr := reflect.New(elemValue.Type()).Elem()
 		elemPtr.Set(elemValue)
 
@@ -239,13 +883,13 @@ func setDefaultsMap(ptr interface{}) (err error) {
 		switch elemValueType.Kind() {
 		case reflect.Struct:
 			// recursively set defaults for struct elements
-			err = setDefaultsStruct(getPtrInterface(elemPtr))
+			err = setDefaultsStruct(opts.ptrTo(elemPtr), opts.at(fmt.Sprint(key)))
 		case reflect.Slice, reflect.Array:
 			// recursively set defaults for slice or array elements
-			err = setDefaultsSlice(getPtrInterface(elemPtr))
+			err = setDefaultsSlice(opts.ptrTo(elemPtr), opts.at(fmt.Sprint(key)))
 		case reflect.Map:
 			// recursively set defaults for map elements
-			err = setDefaultsMap(getPtrInterface(elemPtr))
+			err = setDefaultsMap(opts.ptrTo(elemPtr), opts.at(fmt.Sprint(key)))
 		}
 
 		// if an error occurs during setting defaults, return the error
@@ -260,11 +904,121 @@ func setDefaultsMap(ptr interface{}) (err error) {
 	return nil
 }
 
+// seedMapDefaults adds the key value pairs of a mapdefault tag like "timeout=30,retries=3" to a map,
+// if the key is not present yet. A nil map is created before.
+func seedMapDefaults(fieldValue reflect.Value, mapDefaultTag string, opts *defaultOptions) error {
+	// allocate a nil pointer to the map
+	if fieldValue.Kind() == reflect.Ptr && fieldValue.IsNil() {
+		opts.set(fieldValue, reflect.New(fieldValue.Type().Elem()))
+	}
+
+	// obtain a settable map value and create the map if necessary
+	mapValue := reflect.ValueOf(opts.ptrTo(fieldValue)).Elem()
+	if mapValue.IsNil() {
+		mapValue.Set(reflect.MakeMap(mapValue.Type()))
+	}
+
+	for _, pair := range strings.Split(mapDefaultTag, ",") {
+		keyTag, valueTag, found := strings.Cut(pair, "=")
+		if !found {
+			return errSyntax
+		}
+
+		key, err := parseDefaultValue(strings.TrimSpace(keyTag), "", mapValue.Type().Key())
+		if err != nil {
+			return err
+		}
+
+		// existing keys keep their value
+		if mapValue.MapIndex(key).IsValid() {
+			continue
+		}
+
+		value, err := parseDefaultValue(strings.TrimSpace(valueTag), "", mapValue.Type().Elem())
+		if err != nil {
+			return err
+		}
+		mapValue.SetMapIndex(key, value)
+	}
+
+	return nil
+}
+
+// defaultMacros are the well-known macros, which can be used as default tag of a field.
+// "@now" is not part of it, because it is handled by time fields directly.
+var defaultMacros = map[string]func() (string, error){
+	"@hostname": os.Hostname,
+	"@pid": func() (string, error) {
+		return strconv.Itoa(os.Getpid()), nil
+	},
+}
+
+// now returns the current time, tests replace it by a fixed clock
+var now = time.Now
+
+// SetClock replaces the clock, which determines the current time for "now" and the period macros of defaults.
+// A nil clock restores time.Now. The clock should be set before setting defaults concurrently.
+func SetClock(clock func() time.Time) {
+	if clock == nil {
+		clock = time.Now
+	}
+	now = clock
+}
+
+// periodMacros are the macros of time fields, which determine the start or the end of the current period
+var periodMacros = map[string]func(t time.Time) time.Time{
+	"@startofday": func(t time.Time) time.Time {
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	},
+	"@endofday": func(t time.Time) time.Time {
+		return time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, -1, t.Location())
+	},
+	"@startofmonth": func(t time.Time) time.Time {
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+	},
+	"@endofmonth": func(t time.Time) time.Time {
+		return time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, -1, t.Location())
+	},
+	"@startofyear": func(t time.Time) time.Time {
+		return time.Date(t.Year(), time.January, 1, 0, 0, 0, 0, t.Location())
+	},
+	"@endofyear": func(t time.Time) time.Time {
+		return time.Date(t.Year()+1, time.January, 1, 0, 0, 0, -1, t.Location())
+	},
+}
+
+// parsePeriodDefault determines the time of a period macro relative to now in the time zone of the tz tag.
+// Without a tz tag the local time zone is used.
+func parsePeriodDefault(defaultTag string, tzTag string, fieldType reflect.Type) (reflect.Value, error) {
+	// pointers to time.Time get a new pointee like by parseDefaultValue
+	if fieldType.Kind() == reflect.Ptr {
+		defaultValue, err := parsePeriodDefault(defaultTag, tzTag, fieldType.Elem())
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		ptrValue := reflect.New(fieldType.Elem())
+		ptrValue.Elem().Set(defaultValue)
+		return ptrValue, nil
+	}
+
+	location := time.Local
+	if tzTag != "" {
+		var err error
+		location, err = time.LoadLocation(tzTag)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+	}
+
+	return reflect.ValueOf(periodMacros[defaultTag](now().In(location))), nil
+}
+
 // parseDefaultValue parses the default tag and converts it to a value for scalar data types
 func parseDefaultValue(defaultTag string, layoutTag string, fieldType reflect.Type) (reflect.Value, error) {
 	kind := fieldType.Kind()
 
 	// if the field type is a pointer, process the pointed-to type recursively
+	// and allocate a new pointee, so nil pointers to slices and maps receive their default too
 	if kind == reflect.Ptr {
 		elemType := fieldType.Elem()
 		defaultValue, err := parseDefaultValue(defaultTag, layoutTag, elemType)
@@ -276,6 +1030,15 @@ func parseDefaultValue(defaultTag string, layoutTag string, fieldType reflect.Ty
 		return ptrValue, nil
 	}
 
+	// well-known macros are replaced by their value before the default is parsed
+	if macro, ok := defaultMacros[defaultTag]; ok {
+		value, err := macro()
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		defaultTag = value
+	}
+
 	switch kind {
 	case reflect.String:
 		// for string fields, return a reflect.Value with the defaultTag value
@@ -283,6 +1046,15 @@ func parseDefaultValue(defaultTag string, layoutTag string, fieldType reflect.Ty
 
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
 		if fieldType.String() == "time.Duration" {
+			// clock notations like "02:35:00" or "35:00" are accepted besides the go notation
+			if strings.Contains(defaultTag, ":") {
+				dur, err := parseClockDuration(defaultTag)
+				if err != nil {
+					return reflect.Value{}, err
+				}
+				return reflect.ValueOf(dur), nil
+			}
+
 			dur, err := time.ParseDuration(defaultTag)
 			if err != nil {
 				return reflect.Value{}, errSyntax
@@ -346,8 +1118,13 @@ func parseDefaultValue(defaultTag string, layoutTag string, fieldType reflect.Ty
 
 	case reflect.Struct:
 		if fieldType.String() == "time.Time" {
-			if strings.ToLower(defaultTag) == "now" {
-				return reflect.ValueOf(time.Now()), nil
+			if strings.ToLower(defaultTag) == "now" || defaultTag == "@now" {
+				return reflect.ValueOf(now()), nil
+			}
+
+			// without a layout tag the layout can be written in front of the value, like "Jan 2 2006=Jun 9 1965"
+			if layoutTag == "" && strings.Contains(defaultTag, "=") {
+				layoutTag, defaultTag, _ = strings.Cut(defaultTag, "=")
 			}
 
 			switch strings.ToLower(layoutTag) {
@@ -395,9 +1172,29 @@ func parseDefaultValue(defaultTag string, layoutTag string, fieldType reflect.Ty
 			}
 			return reflect.ValueOf(t), nil
 		}
+		if fieldType.String() == "url.URL" {
+			u, err := url.Parse(defaultTag)
+			if err != nil {
+				return reflect.Value{}, errSyntax
+			}
+			return reflect.ValueOf(*u), nil
+		}
 		return reflect.Value{}, fmt.Errorf("unsupported field type: %s", fieldType.Kind())
 
 	case reflect.Slice, reflect.Array:
+		if fieldType.String() == "net.IP" {
+			ip := net.ParseIP(defaultTag)
+			if ip == nil {
+				return reflect.Value{}, errSyntax
+			}
+			return reflect.ValueOf(ip), nil
+		}
+
+		// encoding/json can not decode complex numbers, so they are parsed element by element
+		if isComplexContainer(fieldType) {
+			return parseComplexContainerDefault(defaultTag, fieldType)
+		}
+
 		defaultValue := reflect.New(fieldType)
 		if err := json.Unmarshal([]byte(defaultTag), defaultValue.Interface()); err != nil {
 			return reflect.Value{}, err
@@ -405,6 +1202,10 @@ func parseDefaultValue(defaultTag string, layoutTag string, fieldType reflect.Ty
 		return defaultValue.Elem().Convert(fieldType), nil
 
 	case reflect.Map:
+		if isComplexContainer(fieldType) {
+			return parseComplexContainerDefault(defaultTag, fieldType)
+		}
+
 		defaultValue := reflect.New(fieldType)
 		if err := json.Unmarshal([]byte(defaultTag), defaultValue.Interface()); err != nil {
 			return reflect.Value{}, err
@@ -416,3 +1217,78 @@ func parseDefaultValue(defaultTag string, layoutTag string, fieldType reflect.Ty
 		return reflect.Value{}, fmt.Errorf("unsupported field type: %s", fieldType.Kind())
 	}
 }
+
+// isComplexContainer reports whether the elements of the slice, array or map are complex numbers or pointers to them
+func isComplexContainer(containerType reflect.Type) bool {
+	elemType := containerType.Elem()
+	for elemType.Kind() == reflect.Ptr {
+		elemType = elemType.Elem()
+	}
+
+	return elemType.Kind() == reflect.Complex64 || elemType.Kind() == reflect.Complex128
+}
+
+// parseComplexContainerDefault decodes a JSON array or object of complex numbers like ["1+2i","3+4i"].
+// Every element is parsed like a default tag of the element type, null keeps the zero value.
+func parseComplexContainerDefault(defaultTag string, containerType reflect.Type) (reflect.Value, error) {
+	elemType := containerType.Elem()
+
+	// parseElement parses a single JSON element, which is a string or a plain JSON number
+	parseElement := func(element json.RawMessage) (reflect.Value, error) {
+		if string(element) == "null" {
+			return reflect.Zero(elemType), nil
+		}
+		var text string
+		if err := json.Unmarshal(element, &text); err != nil {
+			text = string(element)
+		}
+		return parseDefaultValue(text, "", elemType)
+	}
+
+	if containerType.Kind() == reflect.Map {
+		var elements map[string]json.RawMessage
+		if err := json.Unmarshal([]byte(defaultTag), &elements); err != nil {
+			return reflect.Value{}, err
+		}
+
+		defaultValue := reflect.MakeMapWithSize(containerType, len(elements))
+		for keyTag, element := range elements {
+			key, err := parseDefaultValue(keyTag, "", containerType.Key())
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			value, err := parseElement(element)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			defaultValue.SetMapIndex(key.Convert(containerType.Key()), value)
+		}
+		return defaultValue, nil
+	}
+
+	var elements []json.RawMessage
+	if err := json.Unmarshal([]byte(defaultTag), &elements); err != nil {
+		return reflect.Value{}, err
+	}
+
+	var defaultValue reflect.Value
+	if containerType.Kind() == reflect.Array {
+		// like encoding/json, additional elements are ignored for arrays
+		defaultValue = reflect.New(containerType).Elem()
+		if len(elements) > containerType.Len() {
+			elements = elements[:containerType.Len()]
+		}
+	} else {
+		defaultValue = reflect.MakeSlice(containerType, len(elements), len(elements))
+	}
+
+	for i, element := range elements {
+		value, err := parseElement(element)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		defaultValue.Index(i).Set(value)
+	}
+
+	return defaultValue, nil
+}
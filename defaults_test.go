@@ -3,7 +3,11 @@ package piranhas
 import (
 	"errors"
 	"fmt"
+	"net"
+	"net/url"
+	"os"
 	"reflect"
+	"strconv"
 	"testing"
 	"time"
 )
@@ -231,6 +235,69 @@ func TestSetDefaultsComplext(t *testing.T) {
 	}
 }
 
+func TestSetDefaultsComplexJson(t *testing.T) {
+	type signal struct {
+		samples  []complex128          `default:"[\"1+2i\",\"3+4i\"]"`
+		window   [2]complex64          `default:"[\"1+0i\",\"0+1i\",\"2+2i\"]"`
+		poles    map[string]complex128 `default:"{\"low\":\"0.5+0.5i\",\"high\":null}"`
+		indexed  map[int]complex64     `default:"{\"1\":\"1+1i\"}"`
+		pointers []*complex128         `default:"[\"1+1i\",null]"`
+		gains    map[string]float64    `default:"{\"left\":0.5,\"right\":1.5}"`
+	}
+
+	type signalInvalid struct {
+		samples []complex128 `default:"[\"1+2i\",\"three\"]"`
+	}
+
+	pointer := complex(1, 1)
+
+	tests := []struct {
+		name        string
+		input       interface{}
+		expected    interface{}
+		expectedErr error
+	}{
+		{
+			name:  "complex slices, arrays and maps",
+			input: &signal{},
+			expected: &signal{
+				samples:  []complex128{complex(1, 2), complex(3, 4)},
+				window:   [2]complex64{complex(1, 0), complex(0, 1)},
+				poles:    map[string]complex128{"low": complex(0.5, 0.5), "high": 0},
+				indexed:  map[int]complex64{1: complex(1, 1)},
+				pointers: []*complex128{&pointer, nil},
+				gains:    map[string]float64{"left": 0.5, "right": 1.5},
+			},
+		},
+		{
+			name:        "invalid complex element",
+			input:       &signalInvalid{},
+			expected:    &signalInvalid{},
+			expectedErr: errors.New("failed to parse default tag for field samples: invalid syntax"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := SetDefaults(test.input)
+
+			if !reflect.DeepEqual(test.input, test.expected) {
+				t.Errorf("Expected: %+v, but got: %+v", test.expected, test.input)
+			}
+
+			if (err == nil && test.expectedErr != nil) || (err != nil && test.expectedErr == nil) {
+				t.Errorf("Missmatch on expected error")
+			}
+
+			if err != nil && test.expectedErr != nil {
+				if err.Error() != test.expectedErr.Error() {
+					t.Errorf("Expected error: %v, but got: %v", test.expectedErr, err)
+				}
+			}
+		})
+	}
+}
+
 func TestSetDefaultsJson(t *testing.T) {
 	type structurSlice struct {
 		stringSlice    []string  `default:"[\"a\",\"b\"]"`
@@ -306,3 +373,1661 @@ func TestSetDefaultsJson(t *testing.T) {
 		})
 	}
 }
+
+func TestSetDefaultsComputed(t *testing.T) {
+	type rectangle struct {
+		width     int     `default:"20"`
+		height    int     `default:"5"`
+		area      int     `default:"=width*height"`
+		perimeter int     `default:"=2*(width+height)"`
+		ratio     float64 `default:"=width/height"`
+	}
+
+	type rectangleUnknown struct {
+		width int `default:"20"`
+		area  int `default:"=width*depth"`
+	}
+
+	type dimensions struct {
+		width int `default:"20"`
+	}
+
+	type rectanglePromoted struct {
+		*dimensions
+		area int `default:"=width*2"`
+	}
+
+	tests := []struct {
+		name        string
+		input       interface{}
+		expected    interface{}
+		expectedErr error
+	}{
+		{
+			name:     "computed from siblings",
+			input:    &rectangle{},
+			expected: &rectangle{width: 20, height: 5, area: 100, perimeter: 50, ratio: 4},
+		},
+		{
+			name:        "unknown reference",
+			input:       &rectangleUnknown{},
+			expected:    &rectangleUnknown{width: 20},
+			expectedErr: errors.New("failed to parse default tag for field area: unknown field reference"),
+		},
+		{
+			name:        "reference promoted through nil pointer",
+			input:       &rectanglePromoted{},
+			expected:    &rectanglePromoted{},
+			expectedErr: errors.New("failed to parse default tag for field area: referenced field is not numeric"),
+		},
+		{
+			name:     "reference promoted through pointer",
+			input:    &rectanglePromoted{dimensions: &dimensions{}},
+			expected: &rectanglePromoted{dimensions: &dimensions{width: 20}, area: 40},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := SetDefaults(test.input)
+
+			if !reflect.DeepEqual(test.input, test.expected) {
+				t.Errorf("Expected: %+v, but got: %+v", test.expected, test.input)
+			}
+
+			if (err == nil && test.expectedErr != nil) || (err != nil && test.expectedErr == nil) {
+				t.Errorf("Missmatch on expected error")
+			}
+
+			if err != nil && test.expectedErr != nil {
+				if err.Error() != test.expectedErr.Error() {
+					t.Errorf("Expected error: %v, but got: %v", test.expectedErr, err)
+				}
+			}
+		})
+	}
+}
+
+func TestSetDefaultsMapDefault(t *testing.T) {
+	type settings struct {
+		limits map[string]int `mapdefault:"timeout=30, retries=3"`
+	}
+
+	type settingsError struct {
+		limits map[string]int `mapdefault:"timeout=thirty"`
+	}
+
+	tests := []struct {
+		name        string
+		input       interface{}
+		expected    interface{}
+		expectedErr error
+	}{
+		{
+			name:     "nil map",
+			input:    &settings{},
+			expected: &settings{limits: map[string]int{"timeout": 30, "retries": 3}},
+		},
+		{
+			name:     "existing map keeps present keys",
+			input:    &settings{limits: map[string]int{"timeout": 10, "delay": 5}},
+			expected: &settings{limits: map[string]int{"timeout": 10, "retries": 3, "delay": 5}},
+		},
+		{
+			name:        "invalid value",
+			input:       &settingsError{},
+			expected:    &settingsError{limits: map[string]int{}},
+			expectedErr: errors.New("failed to parse mapdefault tag for field limits: invalid syntax"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := SetDefaults(test.input)
+
+			if !reflect.DeepEqual(test.input, test.expected) {
+				t.Errorf("Expected: %+v, but got: %+v", test.expected, test.input)
+			}
+
+			if (err == nil && test.expectedErr != nil) || (err != nil && test.expectedErr == nil) {
+				t.Errorf("Missmatch on expected error")
+			}
+
+			if err != nil && test.expectedErr != nil {
+				if err.Error() != test.expectedErr.Error() {
+					t.Errorf("Expected error: %v, but got: %v", test.expectedErr, err)
+				}
+			}
+		})
+	}
+}
+
+func TestSetDefaultsPointerContainer(t *testing.T) {
+	type address struct {
+		street string
+		ZIP    string
+	}
+
+	type structurPtr struct {
+		intSlicePtr     *[]int          `default:"[1,2,3]"`
+		stringMapPtr    *map[string]int `default:"{\"a\": 5}"`
+		addressSlicePtr *[]address      `default:"[{\"ZIP\":\"10553\"},{\"ZIP\":\"10000\"}]"`
+		addressPtr      *address
+		nilSlicePtr     *[]address
+		nilMapPtr       *map[string]address
+	}
+
+	intSlice := []int{1, 2, 3}
+	stringMap := map[string]int{"a": 5}
+	addressSlice := []address{{ZIP: "10553"}, {ZIP: "10000"}}
+
+	tests := []struct {
+		name        string
+		input       interface{}
+		expected    interface{}
+		expectedErr error
+	}{
+		{
+			name:  "nil pointers receive json defaults",
+			input: &structurPtr{},
+			expected: &structurPtr{
+				intSlicePtr:     &intSlice,
+				stringMapPtr:    &stringMap,
+				addressSlicePtr: &addressSlice,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := SetDefaults(test.input)
+
+			if !reflect.DeepEqual(test.input, test.expected) {
+				t.Errorf("Expected: %+v, but got: %+v", test.expected, test.input)
+			}
+
+			if (err == nil && test.expectedErr != nil) || (err != nil && test.expectedErr == nil) {
+				t.Errorf("Missmatch on expected error")
+			}
+		})
+	}
+}
+
+func TestSetDefaultsLocale(t *testing.T) {
+	type measurement struct {
+		weight    float64  `default:"1,5" locale:"de"`
+		price     float32  `default:"1.234,75" locale:"de-DE"`
+		distance  *float64 `default:"1,234.5" locale:"en_US"`
+		plainSize float64  `default:"2.25"`
+	}
+
+	type measurementAmbiguous struct {
+		weight float64 `default:"1.5" locale:"de"`
+	}
+
+	type measurementUnknown struct {
+		weight float64 `default:"1,5" locale:"xx"`
+	}
+
+	distance := 1234.5
+
+	tests := []struct {
+		name        string
+		input       interface{}
+		expected    interface{}
+		expectedErr error
+	}{
+		{
+			name:  "european and english notation",
+			input: &measurement{},
+			expected: &measurement{
+				weight:    1.5,
+				price:     1234.75,
+				distance:  &distance,
+				plainSize: 2.25,
+			},
+		},
+		{
+			name:        "dot is a thousands separator in german",
+			input:       &measurementAmbiguous{},
+			expected:    &measurementAmbiguous{},
+			expectedErr: errors.New("failed to parse default tag for field weight: invalid format, thousands separator at wrong position"),
+		},
+		{
+			name:        "unknown locale",
+			input:       &measurementUnknown{},
+			expected:    &measurementUnknown{},
+			expectedErr: errors.New("failed to parse default tag for field weight: unknown locale"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := SetDefaults(test.input)
+
+			if !reflect.DeepEqual(test.input, test.expected) {
+				t.Errorf("Expected: %+v, but got: %+v", test.expected, test.input)
+			}
+
+			if (err == nil && test.expectedErr != nil) || (err != nil && test.expectedErr == nil) {
+				t.Errorf("Missmatch on expected error")
+			}
+
+			if err != nil && test.expectedErr != nil {
+				if err.Error() != test.expectedErr.Error() {
+					t.Errorf("Expected error: %v, but got: %v", test.expectedErr, err)
+				}
+			}
+		})
+	}
+}
+
+func TestSetDefaultsTemplate(t *testing.T) {
+	type server struct {
+		url  string `default:"{{.host}}:{{.port}}"`
+		host string `default:"localhost"`
+		port int    `default:"8080"`
+	}
+
+	type serverUnknown struct {
+		url  string `default:"{{.host}}:{{.socket}}"`
+		host string `default:"localhost"`
+	}
+
+	tests := []struct {
+		name        string
+		input       interface{}
+		set         func(ptr interface{}) error
+		expected    interface{}
+		expectedErr error
+	}{
+		{
+			name:     "composite of two siblings",
+			input:    &server{},
+			set:      SetDefaultsTemplate,
+			expected: &server{url: "localhost:8080", host: "localhost", port: 8080},
+		},
+		{
+			name:     "without template mode the default is plain text",
+			input:    &server{},
+			set:      SetDefaults,
+			expected: &server{url: "{{.host}}:{{.port}}", host: "localhost", port: 8080},
+		},
+		{
+			name:        "unknown field",
+			input:       &serverUnknown{},
+			set:         SetDefaultsTemplate,
+			expected:    &serverUnknown{host: "localhost"},
+			expectedErr: errors.New("failed to parse default tag for field url: template: default:1:12: executing \"default\" at <.socket>: map has no entry for key \"socket\""),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.set(test.input)
+
+			if !reflect.DeepEqual(test.input, test.expected) {
+				t.Errorf("Expected: %+v, but got: %+v", test.expected, test.input)
+			}
+
+			if (err == nil && test.expectedErr != nil) || (err != nil && test.expectedErr == nil) {
+				t.Errorf("Missmatch on expected error")
+			}
+
+			if err != nil && test.expectedErr != nil {
+				if err.Error() != test.expectedErr.Error() {
+					t.Errorf("Expected error: %v, but got: %v", test.expectedErr, err)
+				}
+			}
+		})
+	}
+}
+
+func TestSetDefaultsWhen(t *testing.T) {
+	type person struct {
+		name    string `default:"John"`
+		age     int    `default:"30"`
+		city    string `default:"Berlin"`
+		country string `default:"Germany"`
+		isMale  bool   `default:"true"`
+	}
+
+	evenIndex := func(field reflect.StructField, current reflect.Value) bool {
+		return field.Index[len(field.Index)-1]%2 == 0
+	}
+
+	isZero := func(field reflect.StructField, current reflect.Value) bool {
+		return current.IsZero()
+	}
+
+	tests := []struct {
+		name     string
+		input    interface{}
+		pred     func(field reflect.StructField, current reflect.Value) bool
+		expected interface{}
+	}{
+		{
+			name:     "only even-indexed fields",
+			input:    &person{},
+			pred:     evenIndex,
+			expected: &person{name: "John", city: "Berlin", isMale: true},
+		},
+		{
+			name:     "only zero fields",
+			input:    &person{name: "Karl", age: 58},
+			pred:     isZero,
+			expected: &person{name: "Karl", age: 58, city: "Berlin", country: "Germany", isMale: true},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := SetDefaultsWhen(test.input, test.pred)
+			if err != nil {
+				t.Errorf("Expected no error, but got: %v", err)
+			}
+
+			if !reflect.DeepEqual(test.input, test.expected) {
+				t.Errorf("Expected: %+v, but got: %+v", test.expected, test.input)
+			}
+		})
+	}
+}
+
+func TestSetDefaultsArrayOfStructs(t *testing.T) {
+	type address struct {
+		street string `default:"Müllerstraße"`
+		number int    `default:"400"`
+		city   string `default:"Berlin"`
+		ZIP    string `default:"10000"`
+	}
+
+	type person struct {
+		addresses    [2]address
+		addressesPtr *[2]address
+	}
+
+	defaulted := address{"Müllerstraße", 400, "Berlin", "10000"}
+
+	tests := []struct {
+		name     string
+		input    interface{}
+		expected interface{}
+	}{
+		{
+			name:     "array field",
+			input:    &person{},
+			expected: &person{addresses: [2]address{defaulted, defaulted}},
+		},
+		{
+			name:     "pointer to array field",
+			input:    &person{addressesPtr: &[2]address{{street: "Tellerstraße"}, {}}},
+			expected: &person{addresses: [2]address{defaulted, defaulted}, addressesPtr: &[2]address{defaulted, defaulted}},
+		},
+		{
+			name:     "array itself",
+			input:    &[2]address{},
+			expected: &[2]address{defaulted, defaulted},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := SetDefaults(test.input)
+			if err != nil {
+				t.Errorf("Expected no error, but got: %v", err)
+			}
+
+			if !reflect.DeepEqual(test.input, test.expected) {
+				t.Errorf("Expected: %+v, but got: %+v", test.expected, test.input)
+			}
+		})
+	}
+}
+
+func TestSetDefaultsSliceOfPointers(t *testing.T) {
+	type address struct {
+		street string `default:"Müllerstraße"`
+		number int    `default:"400"`
+		city   string `default:"Berlin"`
+	}
+
+	type person struct {
+		addresses []*address
+		allocated []*address  `alloc:"true"`
+		array     [2]*address `alloc:"true"`
+		byName    map[string]*address
+	}
+
+	defaulted := func() *address {
+		return &address{"Müllerstraße", 400, "Berlin"}
+	}
+
+	tests := []struct {
+		name     string
+		input    interface{}
+		expected interface{}
+	}{
+		{
+			name:     "nil elements stay nil",
+			input:    &person{addresses: []*address{nil, {street: "Tellerstraße"}, nil}},
+			expected: &person{addresses: []*address{nil, defaulted(), nil}, array: [2]*address{defaulted(), defaulted()}},
+		},
+		{
+			name:     "nil elements are allocated with the alloc tag",
+			input:    &person{allocated: []*address{nil, {number: 29}}, array: [2]*address{{}, nil}},
+			expected: &person{allocated: []*address{defaulted(), defaulted()}, array: [2]*address{defaulted(), defaulted()}},
+		},
+		{
+			name:     "nil map values stay nil",
+			input:    &person{byName: map[string]*address{"home": nil, "work": {}}},
+			expected: &person{byName: map[string]*address{"home": nil, "work": defaulted()}, array: [2]*address{defaulted(), defaulted()}},
+		},
+		{
+			name:     "slice itself",
+			input:    &[]*address{nil, {}},
+			expected: &[]*address{nil, defaulted()},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := SetDefaults(test.input)
+			if err != nil {
+				t.Errorf("Expected no error, but got: %v", err)
+			}
+
+			if !reflect.DeepEqual(test.input, test.expected) {
+				t.Errorf("Expected: %+v, but got: %+v", test.expected, test.input)
+			}
+		})
+	}
+
+	// the pointees are changed in place, so other references see the defaults
+	shared := &address{}
+	input := &person{addresses: []*address{shared}}
+	if err := SetDefaults(input); err != nil {
+		t.Fatalf("Expected no error, but got: %v", err)
+	}
+	if !reflect.DeepEqual(shared, defaulted()) {
+		t.Errorf("Expected: %+v, but got: %+v", defaulted(), shared)
+	}
+}
+
+func TestSetDefaultsNestedContainers(t *testing.T) {
+	type address struct {
+		street string `default:"Müllerstraße"`
+		number int    `default:"400"`
+		city   string `default:"Berlin"`
+	}
+
+	type person struct {
+		sliceOfMaps []map[string]address
+		mapOfSlices map[string][]address
+		mapOfArrays map[string][2]address
+		mapOfMaps   map[string]map[string][]*address
+		arrayOfMaps [1]map[int]address
+	}
+
+	defaulted := address{"Müllerstraße", 400, "Berlin"}
+
+	tests := []struct {
+		name     string
+		input    interface{}
+		expected interface{}
+	}{
+		{
+			name:     "slice of maps",
+			input:    &person{sliceOfMaps: []map[string]address{{"home": {street: "Tellerstraße"}}, {"work": {}, "club": {number: 29}}}},
+			expected: &person{sliceOfMaps: []map[string]address{{"home": defaulted}, {"work": defaulted, "club": defaulted}}},
+		},
+		{
+			name:     "map of slices",
+			input:    &person{mapOfSlices: map[string][]address{"home": {{}, {city: "Hamburg"}}, "work": {}}},
+			expected: &person{mapOfSlices: map[string][]address{"home": {defaulted, defaulted}, "work": {}}},
+		},
+		{
+			name:     "map of arrays",
+			input:    &person{mapOfArrays: map[string][2]address{"home": {{number: 1}}}},
+			expected: &person{mapOfArrays: map[string][2]address{"home": {defaulted, defaulted}}},
+		},
+		{
+			name:     "map of maps of slices of pointers",
+			input:    &person{mapOfMaps: map[string]map[string][]*address{"outer": {"inner": {{}, nil}}}},
+			expected: &person{mapOfMaps: map[string]map[string][]*address{"outer": {"inner": {&defaulted, nil}}}},
+		},
+		{
+			name:     "array of maps",
+			input:    &person{arrayOfMaps: [1]map[int]address{{7: {}}}},
+			expected: &person{arrayOfMaps: [1]map[int]address{{7: defaulted}}},
+		},
+		{
+			name:     "slice of maps itself",
+			input:    &[]map[string]address{{"home": {}}},
+			expected: &[]map[string]address{{"home": defaulted}},
+		},
+		{
+			name:     "map of slices itself",
+			input:    &map[string][]address{"home": {{}}},
+			expected: &map[string][]address{"home": {defaulted}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := SetDefaults(test.input)
+			if err != nil {
+				t.Errorf("Expected no error, but got: %v", err)
+			}
+
+			if !reflect.DeepEqual(test.input, test.expected) {
+				t.Errorf("Expected: %+v, but got: %+v", test.expected, test.input)
+			}
+		})
+	}
+}
+
+func TestSetDefaultsURLAndIP(t *testing.T) {
+	type endpoint struct {
+		homepage url.URL  `default:"https://example.com/path?q=1"`
+		proxy    *url.URL `default:"http://proxy:3128"`
+		address  net.IP   `default:"10.0.0.1"`
+		gateway  net.IP   `default:"::1"`
+	}
+
+	type endpointInvalidURL struct {
+		homepage url.URL `default:"https://exa mple.com"`
+	}
+
+	type endpointInvalidIP struct {
+		address net.IP `default:"10.0.0.256"`
+	}
+
+	tests := []struct {
+		name        string
+		input       interface{}
+		expected    interface{}
+		expectedErr error
+	}{
+		{
+			name:  "valid url and ip defaults",
+			input: &endpoint{},
+			expected: &endpoint{
+				homepage: url.URL{Scheme: "https", Host: "example.com", Path: "/path", RawQuery: "q=1"},
+				proxy:    &url.URL{Scheme: "http", Host: "proxy:3128"},
+				address:  net.ParseIP("10.0.0.1"),
+				gateway:  net.ParseIP("::1"),
+			},
+		},
+		{
+			name:        "invalid url",
+			input:       &endpointInvalidURL{},
+			expected:    &endpointInvalidURL{},
+			expectedErr: errors.New("failed to parse default tag for field homepage: invalid syntax"),
+		},
+		{
+			name:        "invalid ip",
+			input:       &endpointInvalidIP{},
+			expected:    &endpointInvalidIP{},
+			expectedErr: errors.New("failed to parse default tag for field address: invalid syntax"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := SetDefaults(test.input)
+
+			if !reflect.DeepEqual(test.input, test.expected) {
+				t.Errorf("Expected: %+v, but got: %+v", test.expected, test.input)
+			}
+
+			if (err == nil && test.expectedErr != nil) || (err != nil && test.expectedErr == nil) {
+				t.Errorf("Missmatch on expected error")
+			}
+
+			if err != nil && test.expectedErr != nil {
+				if err.Error() != test.expectedErr.Error() {
+					t.Errorf("Expected error: %v, but got: %v", test.expectedErr, err)
+				}
+			}
+		})
+	}
+}
+
+func TestSetDefaultsTx(t *testing.T) {
+	type inner struct {
+		level int `default:"3"`
+	}
+
+	type config struct {
+		name    string `default:"server"`
+		inner   *inner
+		items   []inner
+		entries map[string]*inner
+		created time.Time
+		broken  int `default:"many"`
+	}
+
+	type configValid struct {
+		name  string `default:"server"`
+		inner *inner
+	}
+
+	created := time.Date(2020, time.March, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		input       interface{}
+		expected    interface{}
+		expectedErr error
+	}{
+		{
+			name: "error restores the original",
+			input: &config{
+				name:    "",
+				inner:   &inner{},
+				items:   []inner{{level: 1}, {}},
+				entries: map[string]*inner{"a": {}},
+				created: created,
+			},
+			expected: &config{
+				name:    "",
+				inner:   &inner{},
+				items:   []inner{{level: 1}, {}},
+				entries: map[string]*inner{"a": {}},
+				created: created,
+			},
+			expectedErr: errors.New("failed to parse default tag for field broken: invalid syntax"),
+		},
+		{
+			name:  "success sets the defaults",
+			input: &configValid{inner: &inner{}},
+			expected: &configValid{
+				name:  "server",
+				inner: &inner{level: 3},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := SetDefaultsTx(test.input)
+
+			if !reflect.DeepEqual(test.input, test.expected) {
+				t.Errorf("Expected: %+v, but got: %+v", test.expected, test.input)
+			}
+
+			if (err == nil && test.expectedErr != nil) || (err != nil && test.expectedErr == nil) {
+				t.Errorf("Missmatch on expected error")
+			}
+
+			if err != nil && test.expectedErr != nil {
+				if err.Error() != test.expectedErr.Error() {
+					t.Errorf("Expected error: %v, but got: %v", test.expectedErr, err)
+				}
+			}
+		})
+	}
+
+	// a cycle of pointers is restored in place, so the original pointers form the cycle again
+	type node struct {
+		name   string `default:"node"`
+		next   *node
+		broken int `default:"many"`
+	}
+
+	cyclic := &node{}
+	next := &node{next: cyclic}
+	cyclic.next = next
+	if err := SetDefaultsTx(cyclic); err == nil {
+		t.Fatalf("Expected an error, but got none")
+	}
+	if cyclic.name != "" || next.name != "" || cyclic.next != next || next.next != cyclic {
+		t.Errorf("Expected the cyclic original, but got: %+v", cyclic)
+	}
+
+	// pointers, slices and maps shared with other values keep their identity and their content is restored
+	type shared struct {
+		level int `default:"5"`
+	}
+
+	type holder struct {
+		inner   *shared
+		items   []shared
+		entries map[string]*shared
+		broken  int `default:"many"`
+	}
+
+	pointee := &shared{}
+	items := []shared{{level: 1}, {}}
+	entries := map[string]*shared{"a": {}}
+	entry := entries["a"]
+	value := &holder{inner: pointee, items: items, entries: entries}
+	if err := SetDefaultsTx(value); err == nil {
+		t.Fatalf("Expected an error, but got none")
+	}
+	if value.inner != pointee || pointee.level != 0 {
+		t.Errorf("Expected the original pointer with level 0, but got: %p with %+v", value.inner, value.inner)
+	}
+	if &value.items[0] != &items[0] || items[0].level != 1 || items[1].level != 0 {
+		t.Errorf("Expected the original slice with levels 1 and 0, but got: %+v", value.items)
+	}
+	if reflect.ValueOf(value.entries).Pointer() != reflect.ValueOf(entries).Pointer() || value.entries["a"] != entry || entry.level != 0 {
+		t.Errorf("Expected the original map with level 0, but got: %+v", value.entries)
+	}
+}
+
+func TestSetDefaultsTimeInlineLayout(t *testing.T) {
+	type events struct {
+		birthDate time.Time `default:"Jan 2 2006=Jun 9 1965"`
+		meeting   time.Time `default:"02.01.2006 15:04=04.09.1990 13:30"`
+		release   time.Time `default:"2006-01-02=2023-02-01"`
+		named     time.Time `default:"dateonly=2021-12-24"`
+		tagged    time.Time `default:"04.09.1990" layout:"02.01.2006"`
+	}
+
+	type eventsInvalid struct {
+		birthDate time.Time `default:"Jan 2 2006=9 Jun 1965"`
+	}
+
+	tests := []struct {
+		name        string
+		input       interface{}
+		expected    interface{}
+		expectedErr error
+	}{
+		{
+			name:  "inline layouts",
+			input: &events{},
+			expected: &events{
+				birthDate: time.Date(1965, time.June, 9, 0, 0, 0, 0, time.UTC),
+				meeting:   time.Date(1990, time.September, 4, 13, 30, 0, 0, time.UTC),
+				release:   time.Date(2023, time.February, 1, 0, 0, 0, 0, time.UTC),
+				named:     time.Date(2021, time.December, 24, 0, 0, 0, 0, time.UTC),
+				tagged:    time.Date(1990, time.September, 4, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:        "value does not match the inline layout",
+			input:       &eventsInvalid{},
+			expected:    &eventsInvalid{},
+			expectedErr: errors.New("failed to parse default tag for field birthDate: invalid syntax"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := SetDefaults(test.input)
+
+			if !reflect.DeepEqual(test.input, test.expected) {
+				t.Errorf("Expected: %+v, but got: %+v", test.expected, test.input)
+			}
+
+			if (err == nil && test.expectedErr != nil) || (err != nil && test.expectedErr == nil) {
+				t.Errorf("Missmatch on expected error")
+			}
+
+			if err != nil && test.expectedErr != nil {
+				if err.Error() != test.expectedErr.Error() {
+					t.Errorf("Expected error: %v, but got: %v", test.expectedErr, err)
+				}
+			}
+		})
+	}
+}
+
+func TestSetDefaultsEnv(t *testing.T) {
+	type database struct {
+		url      string        `env:"PIRANHAS_TEST_DATABASE_URL" default:"postgres://localhost"`
+		port     int           `env:"PIRANHAS_TEST_DATABASE_PORT" default:"5432"`
+		timeout  time.Duration `env:"PIRANHAS_TEST_DATABASE_TIMEOUT" default:"5s"`
+		replicas []string      `env:"PIRANHAS_TEST_DATABASE_REPLICAS" default:"[\"a\"]"`
+		user     string        `env:"PIRANHAS_TEST_DATABASE_USER"`
+		size     int           `env:"PIRANHAS_TEST_DATABASE_SIZE" default:"=port*2"`
+	}
+
+	type databaseInvalid struct {
+		port int `env:"PIRANHAS_TEST_DATABASE_PORT" default:"5432"`
+	}
+
+	tests := []struct {
+		name        string
+		env         map[string]string
+		input       interface{}
+		expected    interface{}
+		expectedErr error
+	}{
+		{
+			name: "environment variables win",
+			env: map[string]string{
+				"PIRANHAS_TEST_DATABASE_URL":      "postgres://db.example.com",
+				"PIRANHAS_TEST_DATABASE_PORT":     "6543",
+				"PIRANHAS_TEST_DATABASE_TIMEOUT":  "1m",
+				"PIRANHAS_TEST_DATABASE_REPLICAS": "[\"b\",\"c\"]",
+				"PIRANHAS_TEST_DATABASE_USER":     "karl",
+				"PIRANHAS_TEST_DATABASE_SIZE":     "10",
+			},
+			input: &database{},
+			expected: &database{
+				url:      "postgres://db.example.com",
+				port:     6543,
+				timeout:  time.Minute,
+				replicas: []string{"b", "c"},
+				user:     "karl",
+				size:     10,
+			},
+		},
+		{
+			name:  "default tags are the fallback",
+			env:   map[string]string{},
+			input: &database{},
+			expected: &database{
+				url:      "postgres://localhost",
+				port:     5432,
+				timeout:  5 * time.Second,
+				replicas: []string{"a"},
+				user:     "",
+				size:     10864,
+			},
+		},
+		{
+			name:        "invalid environment variable",
+			env:         map[string]string{"PIRANHAS_TEST_DATABASE_PORT": "many"},
+			input:       &databaseInvalid{},
+			expected:    &databaseInvalid{},
+			expectedErr: errors.New("failed to parse default tag for field port: invalid syntax"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			for key, value := range test.env {
+				t.Setenv(key, value)
+			}
+
+			err := SetDefaultsEnv(test.input)
+
+			if !reflect.DeepEqual(test.input, test.expected) {
+				t.Errorf("Expected: %+v, but got: %+v", test.expected, test.input)
+			}
+
+			if (err == nil && test.expectedErr != nil) || (err != nil && test.expectedErr == nil) {
+				t.Errorf("Missmatch on expected error")
+			}
+
+			if err != nil && test.expectedErr != nil {
+				if err.Error() != test.expectedErr.Error() {
+					t.Errorf("Expected error: %v, but got: %v", test.expectedErr, err)
+				}
+			}
+		})
+	}
+}
+
+func TestSetDefaultsEnum(t *testing.T) {
+	type deployment struct {
+		stage    string `default:"prod" enum:"dev,staging,prod"`
+		replicas int    `default:"3" enum:"1, 3, 5"`
+		region   string `default:"eu"`
+	}
+
+	type deploymentInvalid struct {
+		stage string `default:"production" enum:"dev,staging,prod"`
+	}
+
+	tests := []struct {
+		name        string
+		input       interface{}
+		expected    interface{}
+		expectedErr error
+	}{
+		{
+			name:  "default is a member of the enum",
+			input: &deployment{},
+			expected: &deployment{
+				stage:    "prod",
+				replicas: 3,
+				region:   "eu",
+			},
+		},
+		{
+			name:        "default is not a member of the enum",
+			input:       &deploymentInvalid{},
+			expected:    &deploymentInvalid{},
+			expectedErr: errors.New("failed to parse default tag for field stage: value is not a member of the enum"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := SetDefaults(test.input)
+
+			if !reflect.DeepEqual(test.input, test.expected) {
+				t.Errorf("Expected: %+v, but got: %+v", test.expected, test.input)
+			}
+
+			if (err == nil && test.expectedErr != nil) || (err != nil && test.expectedErr == nil) {
+				t.Errorf("Missmatch on expected error")
+			}
+
+			if err != nil && test.expectedErr != nil {
+				if err.Error() != test.expectedErr.Error() {
+					t.Errorf("Expected error: %v, but got: %v", test.expectedErr, err)
+				}
+			}
+		})
+	}
+}
+
+func TestSetDefaultsJsonStructSlice(t *testing.T) {
+	type stop struct {
+		City string
+		ZIP  string `default:"10000"`
+		Rank int    `default:"1"`
+	}
+
+	type route struct {
+		stops    []stop   `default:"[{\"city\":\"A\"},{\"city\":\"B\",\"zip\":\"20000\"}]"`
+		ptrStops []*stop  `default:"[{\"city\":\"C\"},null]"`
+		pair     [2]stop  `default:"[{\"city\":\"D\",\"rank\":2}]"`
+		optional *[]stop  `default:"[{\"city\":\"E\"}]"`
+		names    []string `default:"[\"x\",\"y\"]"`
+	}
+
+	type routeInvalid struct {
+		stops []stop `default:"[{\"city\":\"A\"}"`
+	}
+
+	tests := []struct {
+		name        string
+		input       interface{}
+		expected    interface{}
+		expectedErr error
+	}{
+		{
+			name:  "json sets the given fields and tags fill the rest",
+			input: &route{},
+			expected: &route{
+				stops: []stop{
+					{City: "A", ZIP: "10000", Rank: 1},
+					{City: "B", ZIP: "20000", Rank: 1},
+				},
+				ptrStops: []*stop{{City: "C", ZIP: "10000", Rank: 1}, nil},
+				pair:     [2]stop{{City: "D", ZIP: "10000", Rank: 2}, {}},
+				optional: &[]stop{{City: "E", ZIP: "10000", Rank: 1}},
+				names:    []string{"x", "y"},
+			},
+		},
+		{
+			name:        "incomplete json",
+			input:       &routeInvalid{},
+			expected:    &routeInvalid{},
+			expectedErr: errors.New("failed to parse default tag for field stops: unexpected end of JSON input"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := SetDefaults(test.input)
+
+			if !reflect.DeepEqual(test.input, test.expected) {
+				t.Errorf("Expected: %+v, but got: %+v", test.expected, test.input)
+			}
+
+			if (err == nil && test.expectedErr != nil) || (err != nil && test.expectedErr == nil) {
+				t.Errorf("Missmatch on expected error")
+			}
+
+			if err != nil && test.expectedErr != nil {
+				if err.Error() != test.expectedErr.Error() {
+					t.Errorf("Expected error: %v, but got: %v", test.expectedErr, err)
+				}
+			}
+		})
+	}
+}
+
+func TestSetDefaultsClockDuration(t *testing.T) {
+	type timer struct {
+		long    time.Duration  `default:"02:35:00"`
+		short   time.Duration  `default:"35:00"`
+		seconds time.Duration  `default:"00:00:45"`
+		hours   time.Duration  `default:"36:00:00"`
+		plain   time.Duration  `default:"2h35m"`
+		ptr     *time.Duration `default:"01:30"`
+	}
+
+	type timerInvalidMinutes struct {
+		long time.Duration `default:"02:75:00"`
+	}
+
+	type timerInvalidParts struct {
+		long time.Duration `default:"1:02:03:04"`
+	}
+
+	type timerOverflow struct {
+		long time.Duration `default:"4294967295:00:00"`
+	}
+
+	type timerOverflowSum struct {
+		long time.Duration `default:"2562047:47:17"`
+	}
+
+	ptr := 90 * time.Second
+
+	tests := []struct {
+		name        string
+		input       interface{}
+		expected    interface{}
+		expectedErr error
+	}{
+		{
+			name:  "clock and go notation",
+			input: &timer{},
+			expected: &timer{
+				long:    2*time.Hour + 35*time.Minute,
+				short:   35 * time.Minute,
+				seconds: 45 * time.Second,
+				hours:   36 * time.Hour,
+				plain:   2*time.Hour + 35*time.Minute,
+				ptr:     &ptr,
+			},
+		},
+		{
+			name:        "minutes out of range",
+			input:       &timerInvalidMinutes{},
+			expected:    &timerInvalidMinutes{},
+			expectedErr: errors.New("failed to parse default tag for field long: invalid syntax"),
+		},
+		{
+			name:        "too many parts",
+			input:       &timerInvalidParts{},
+			expected:    &timerInvalidParts{},
+			expectedErr: errors.New("failed to parse default tag for field long: invalid syntax"),
+		},
+		{
+			name:        "hours out of range",
+			input:       &timerOverflow{},
+			expected:    &timerOverflow{},
+			expectedErr: errors.New("failed to parse default tag for field long: duration is out of range"),
+		},
+		{
+			name:        "sum out of range",
+			input:       &timerOverflowSum{},
+			expected:    &timerOverflowSum{},
+			expectedErr: errors.New("failed to parse default tag for field long: duration is out of range"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := SetDefaults(test.input)
+
+			if !reflect.DeepEqual(test.input, test.expected) {
+				t.Errorf("Expected: %+v, but got: %+v", test.expected, test.input)
+			}
+
+			if (err == nil && test.expectedErr != nil) || (err != nil && test.expectedErr == nil) {
+				t.Errorf("Missmatch on expected error")
+			}
+
+			if err != nil && test.expectedErr != nil {
+				if err.Error() != test.expectedErr.Error() {
+					t.Errorf("Expected error: %v, but got: %v", test.expectedErr, err)
+				}
+			}
+		})
+	}
+}
+
+type box[T any] struct {
+	value T
+	label string `default:"box"`
+}
+
+type pair[K comparable, V any] struct {
+	key    K
+	values map[K]V
+}
+
+func TestSetDefaultsGeneric(t *testing.T) {
+	type intBox struct {
+		value int    `default:"42"`
+		label string `default:"int"`
+	}
+
+	type container struct {
+		counter box[intBox]
+		ptr     *box[int]
+		boxes   []box[string]
+		pairs   map[string]pair[string, intBox]
+	}
+
+	tests := []struct {
+		name        string
+		input       interface{}
+		expected    interface{}
+		expectedErr error
+	}{
+		{
+			name: "instantiated generic structs",
+			input: &container{
+				ptr:   &box[int]{value: 7},
+				boxes: []box[string]{{value: "a"}, {value: "b", label: "custom"}},
+				pairs: map[string]pair[string, intBox]{
+					"first": {key: "first", values: map[string]intBox{"x": {}}},
+				},
+			},
+			expected: &container{
+				counter: box[intBox]{value: intBox{value: 42, label: "int"}, label: "box"},
+				ptr:     &box[int]{value: 7, label: "box"},
+				boxes:   []box[string]{{value: "a", label: "box"}, {value: "b", label: "box"}},
+				pairs: map[string]pair[string, intBox]{
+					"first": {key: "first", values: map[string]intBox{"x": {value: 42, label: "int"}}},
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := SetDefaults(test.input)
+
+			if !reflect.DeepEqual(test.input, test.expected) {
+				t.Errorf("Expected: %+v, but got: %+v", test.expected, test.input)
+			}
+
+			if (err == nil && test.expectedErr != nil) || (err != nil && test.expectedErr == nil) {
+				t.Errorf("Missmatch on expected error")
+			}
+
+			if err != nil && test.expectedErr != nil {
+				if err.Error() != test.expectedErr.Error() {
+					t.Errorf("Expected error: %v, but got: %v", test.expectedErr, err)
+				}
+			}
+		})
+	}
+}
+
+func TestSetDefaultsExportedOnly(t *testing.T) {
+	type Endpoint struct {
+		Host string `default:"localhost"`
+		port int    `default:"80"`
+	}
+
+	type Service struct {
+		Name      string        `default:"api"`
+		secret    string        `default:"hidden"`
+		Timeout   time.Duration `default:"5s"`
+		Endpoint  Endpoint
+		internal  Endpoint
+		Replicas  []Endpoint
+		Labels    map[string]string `mapdefault:"team=core"`
+		Endpoints map[string]*Endpoint
+		Double    int `default:"=Retries*2"`
+		Retries   int `default:"3"`
+	}
+
+	type ServiceInvalid struct {
+		Port    int `default:"many"`
+		invalid int `default:"many"`
+	}
+
+	type Credentials struct {
+		User     string `default:"guest"`
+		password string
+	}
+
+	type ServiceProfiled struct {
+		Credentials Credentials `defaults:"piranhas-test-exported"`
+	}
+
+	if err := RegisterProfile("piranhas-test-exported", Credentials{User: "admin", password: "secret"}); err != nil {
+		t.Fatalf("Failed to register profile: %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		input       interface{}
+		expected    interface{}
+		expectedErr error
+	}{
+		{
+			name: "only exported fields get defaults",
+			input: &Service{
+				Replicas:  []Endpoint{{}, {Host: "replica"}},
+				Endpoints: map[string]*Endpoint{"primary": {}},
+			},
+			expected: &Service{
+				Name:      "api",
+				Timeout:   5 * time.Second,
+				Endpoint:  Endpoint{Host: "localhost"},
+				Replicas:  []Endpoint{{Host: "localhost"}, {Host: "localhost"}},
+				Labels:    map[string]string{"team": "core"},
+				Endpoints: map[string]*Endpoint{"primary": {Host: "localhost"}},
+				Double:    6,
+				Retries:   3,
+			},
+		},
+		{
+			name:        "unexported fields with invalid defaults are not parsed",
+			input:       &ServiceInvalid{},
+			expected:    &ServiceInvalid{},
+			expectedErr: errors.New("failed to parse default tag for field Port: invalid syntax"),
+		},
+		{
+			name:     "slice of exported structs",
+			input:    &[]Endpoint{{}},
+			expected: &[]Endpoint{{Host: "localhost"}},
+		},
+		{
+			name:     "profile of a field is not applied",
+			input:    &ServiceProfiled{},
+			expected: &ServiceProfiled{Credentials: Credentials{User: "guest"}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := SetDefaultsExportedOnly(test.input)
+
+			if !reflect.DeepEqual(test.input, test.expected) {
+				t.Errorf("Expected: %+v, but got: %+v", test.expected, test.input)
+			}
+
+			if (err == nil && test.expectedErr != nil) || (err != nil && test.expectedErr == nil) {
+				t.Errorf("Missmatch on expected error")
+			}
+
+			if err != nil && test.expectedErr != nil {
+				if err.Error() != test.expectedErr.Error() {
+					t.Errorf("Expected error: %v, but got: %v", test.expectedErr, err)
+				}
+			}
+		})
+	}
+}
+
+func TestSetDefaultsMacros(t *testing.T) {
+	type process struct {
+		host      string    `default:"@hostname"`
+		hostPtr   *string   `default:"@hostname"`
+		pid       int       `default:"@pid"`
+		pidString string    `default:"@pid"`
+		started   time.Time `default:"@now"`
+	}
+
+	type processUnknown struct {
+		count uint8 `default:"@unknown"`
+	}
+
+	hostname, err := os.Hostname()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	before := time.Now()
+	input := &process{}
+	if err := SetDefaults(input); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	after := time.Now()
+
+	if input.host != hostname || input.hostPtr == nil || *input.hostPtr != hostname {
+		t.Errorf("Expected hostname %s, but got %s", hostname, input.host)
+	}
+	if input.pid != os.Getpid() || input.pid <= 0 {
+		t.Errorf("Expected pid %d, but got %d", os.Getpid(), input.pid)
+	}
+	if input.pidString != strconv.Itoa(os.Getpid()) {
+		t.Errorf("Expected pid %d, but got %s", os.Getpid(), input.pidString)
+	}
+	if input.started.Before(before) || input.started.After(after) {
+		t.Errorf("Expected a time between %v and %v, but got %v", before, after, input.started)
+	}
+
+	// unknown macros are parsed like any other default
+	err = SetDefaults(&processUnknown{})
+	expectedErr := "failed to parse default tag for field count: invalid syntax"
+	if err == nil || err.Error() != expectedErr {
+		t.Errorf("Expected error: %v, but got: %v", expectedErr, err)
+	}
+}
+
+func TestSetDefaultsTrim(t *testing.T) {
+	type Name string
+
+	type user struct {
+		login    string  `default:"  karl  " trim:"space"`
+		email    string  `default:"Karl@Example.COM" trim:"lower"`
+		country  string  `default:"de" trim:"upper"`
+		fullName string  `default:"karl RANSEIER" trim:"title"`
+		city     *string `default:" berlin " trim:"space,title"`
+		nickname Name    `trim:"space,lower"`
+		missing  *string `trim:"space"`
+	}
+
+	type userUnknown struct {
+		login string `default:"karl" trim:"reverse"`
+	}
+
+	type userNoString struct {
+		age int `default:"58" trim:"space"`
+	}
+
+	city := "Berlin"
+
+	tests := []struct {
+		name        string
+		input       interface{}
+		expected    interface{}
+		expectedErr error
+	}{
+		{
+			name:  "normalized defaults and pre-set values",
+			input: &user{nickname: "  KARLI "},
+			expected: &user{
+				login:    "karl",
+				email:    "karl@example.com",
+				country:  "DE",
+				fullName: "Karl Ranseier",
+				city:     &city,
+				nickname: "karli",
+			},
+		},
+		{
+			name:        "unknown normalization",
+			input:       &userUnknown{},
+			expected:    &userUnknown{login: "karl"},
+			expectedErr: errors.New("failed to parse trim tag for field login: unknown trim normalization"),
+		},
+		{
+			name:        "field is not a string",
+			input:       &userNoString{},
+			expected:    &userNoString{age: 58},
+			expectedErr: errors.New("failed to parse trim tag for field age: trim tag requires a string field"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := SetDefaults(test.input)
+
+			if !reflect.DeepEqual(test.input, test.expected) {
+				t.Errorf("Expected: %+v, but got: %+v", test.expected, test.input)
+			}
+
+			if (err == nil && test.expectedErr != nil) || (err != nil && test.expectedErr == nil) {
+				t.Errorf("Missmatch on expected error")
+			}
+
+			if err != nil && test.expectedErr != nil {
+				if err.Error() != test.expectedErr.Error() {
+					t.Errorf("Expected error: %v, but got: %v", test.expectedErr, err)
+				}
+			}
+		})
+	}
+}
+
+func TestSetDefaultsPeriodMacros(t *testing.T) {
+	type report struct {
+		dayStart   time.Time `default:"@startofday" tz:"UTC"`
+		dayEnd     time.Time `default:"@endofday" tz:"UTC"`
+		monthStart time.Time `default:"@startofmonth" tz:"UTC"`
+		monthEnd   time.Time `default:"@endofmonth" tz:"UTC"`
+		yearStart  time.Time `default:"@startofyear" tz:"UTC"`
+		yearEnd    time.Time `default:"@endofyear" tz:"UTC"`
+		berlinDay  time.Time `default:"@startofday" tz:"Europe/Berlin"`
+		created    time.Time `default:"now"`
+	}
+
+	type reportPointers struct {
+		dayStart *time.Time `default:"@startofday" tz:"UTC"`
+		created  *time.Time `default:"@now"`
+	}
+
+	type reportInvalid struct {
+		dayStart time.Time `default:"@startofday" tz:"Mars/Olympus"`
+	}
+
+	// 23:30 UTC is already the next day in Berlin
+	fixed := time.Date(2024, time.February, 14, 23, 30, 15, 500, time.UTC)
+	SetClock(func() time.Time { return fixed })
+	defer SetClock(nil)
+
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	dayStart := time.Date(2024, time.February, 14, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		input       interface{}
+		expected    interface{}
+		expectedErr error
+	}{
+		{
+			name:  "periods relative to now",
+			input: &report{},
+			expected: &report{
+				dayStart:   time.Date(2024, time.February, 14, 0, 0, 0, 0, time.UTC),
+				dayEnd:     time.Date(2024, time.February, 14, 23, 59, 59, 999999999, time.UTC),
+				monthStart: time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC),
+				monthEnd:   time.Date(2024, time.February, 29, 23, 59, 59, 999999999, time.UTC),
+				yearStart:  time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC),
+				yearEnd:    time.Date(2024, time.December, 31, 23, 59, 59, 999999999, time.UTC),
+				berlinDay:  time.Date(2024, time.February, 15, 0, 0, 0, 0, berlin),
+				created:    fixed,
+			},
+		},
+		{
+			name:     "pointers to time",
+			input:    &reportPointers{},
+			expected: &reportPointers{dayStart: &dayStart, created: &fixed},
+		},
+		{
+			name:        "unknown time zone",
+			input:       &reportInvalid{},
+			expected:    &reportInvalid{},
+			expectedErr: errors.New("failed to parse default tag for field dayStart: unknown time zone Mars/Olympus"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := SetDefaults(test.input)
+
+			if !reflect.DeepEqual(test.input, test.expected) {
+				t.Errorf("Expected: %+v, but got: %+v", test.expected, test.input)
+			}
+
+			if (err == nil && test.expectedErr != nil) || (err != nil && test.expectedErr == nil) {
+				t.Errorf("Missmatch on expected error")
+			}
+
+			if err != nil && test.expectedErr != nil {
+				if err.Error() != test.expectedErr.Error() {
+					t.Errorf("Expected error: %v, but got: %v", test.expectedErr, err)
+				}
+			}
+		})
+	}
+}
+
+func TestSetClock(t *testing.T) {
+	type event struct {
+		created  time.Time `default:"now"`
+		received time.Time `default:"@now"`
+		dayStart time.Time `default:"@startofday" tz:"UTC"`
+	}
+
+	fixed := time.Date(2023, time.June, 9, 3, 4, 5, 6, time.UTC)
+	SetClock(func() time.Time { return fixed })
+	defer SetClock(nil)
+
+	input := &event{}
+	if err := SetDefaults(input); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := &event{
+		created:  fixed,
+		received: fixed,
+		dayStart: time.Date(2023, time.June, 9, 0, 0, 0, 0, time.UTC),
+	}
+	if !reflect.DeepEqual(input, expected) {
+		t.Errorf("Expected: %+v, but got: %+v", expected, input)
+	}
+
+	// a nil clock restores the current time
+	SetClock(nil)
+	before := time.Now()
+	input = &event{}
+	if err := SetDefaults(input); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if input.created.Before(before) || input.created.After(time.Now()) {
+		t.Errorf("Expected the current time, but got: %v", input.created)
+	}
+}
+
+type hookEndpoint struct {
+	host string `default:"localhost"`
+	port int    `default:"8080"`
+	addr string
+}
+
+// AfterDefaults derives the address from host and port
+func (e *hookEndpoint) AfterDefaults() error {
+	e.addr = fmt.Sprintf("%s:%d", e.host, e.port)
+	return nil
+}
+
+type hookService struct {
+	hookEndpoint
+	replicas []hookEndpoint
+	name     string `default:"api"`
+	summary  string
+}
+
+// AfterDefaults replaces the one of the embedded endpoint, so it derives the address itself
+func (s *hookService) AfterDefaults() error {
+	if err := s.hookEndpoint.AfterDefaults(); err != nil {
+		return err
+	}
+	s.summary = fmt.Sprintf("%s@%s", s.name, s.addr)
+	return nil
+}
+
+type hookValidated struct {
+	retries int `default:"-1"`
+	checked bool
+}
+
+// AfterDefaults rejects a negative number of retries
+func (v *hookValidated) AfterDefaults() error {
+	if v.retries < 0 {
+		return errors.New("retries must not be negative")
+	}
+	v.checked = true
+	return nil
+}
+
+type hookAborted struct {
+	validated hookValidated
+	name      string `default:"job"`
+}
+
+type hookCounted struct {
+	name  string `default:"counted"`
+	calls int
+}
+
+// AfterDefaults counts its calls
+func (c *hookCounted) AfterDefaults() error {
+	c.calls++
+	return nil
+}
+
+type hookPromoted struct {
+	hookCounted
+	label string `default:"outer"`
+}
+
+type hookPromotedPtr struct {
+	label string `default:"outer"`
+	*hookCounted
+}
+
+func TestSetDefaultsAfterDefaults(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       interface{}
+		expected    interface{}
+		expectedErr error
+	}{
+		{
+			name:  "derived fields of embedded and outer structs",
+			input: &hookService{replicas: []hookEndpoint{{host: "replica"}}},
+			expected: &hookService{
+				hookEndpoint: hookEndpoint{host: "localhost", port: 8080, addr: "localhost:8080"},
+				replicas:     []hookEndpoint{{host: "localhost", port: 8080, addr: "localhost:8080"}},
+				name:         "api",
+				summary:      "api@localhost:8080",
+			},
+		},
+		{
+			name:     "promoted hook is called once",
+			input:    &hookPromoted{},
+			expected: &hookPromoted{hookCounted: hookCounted{name: "counted", calls: 1}, label: "outer"},
+		},
+		{
+			name:     "hook promoted from an embedded pointer is called once",
+			input:    &hookPromotedPtr{hookCounted: &hookCounted{}},
+			expected: &hookPromotedPtr{label: "outer", hookCounted: &hookCounted{name: "counted", calls: 1}},
+		},
+		{
+			name:        "error of the hook aborts",
+			input:       &hookAborted{},
+			expected:    &hookAborted{validated: hookValidated{retries: -1}},
+			expectedErr: errors.New("failed to finish defaults of piranhas.hookValidated: retries must not be negative"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := SetDefaults(test.input)
+
+			if !reflect.DeepEqual(test.input, test.expected) {
+				t.Errorf("Expected: %+v, but got: %+v", test.expected, test.input)
+			}
+
+			if (err == nil && test.expectedErr != nil) || (err != nil && test.expectedErr == nil) {
+				t.Errorf("Missmatch on expected error")
+			}
+
+			if err != nil && test.expectedErr != nil {
+				if err.Error() != test.expectedErr.Error() {
+					t.Errorf("Expected error: %v, but got: %v", test.expectedErr, err)
+				}
+			}
+		})
+	}
+
+	// a result records the error of the hook with the path of the struct and goes on
+	input := &hookAborted{}
+	result, err := SetDefaultsResult(input)
+	expectedErr := "failed to finish defaults of piranhas.hookValidated: retries must not be negative"
+	if err == nil || err.Error() != expectedErr {
+		t.Errorf("Expected error %v, but got %v", expectedErr, err)
+	}
+	if len(result.Failed) != 1 || result.Failed[0].Path != "validated" {
+		t.Errorf("Expected the failed path validated, but got %+v", result.Failed)
+	}
+	if input.name != "job" {
+		t.Errorf("Expected the name job, but got %s", input.name)
+	}
+}
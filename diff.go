@@ -0,0 +1,84 @@
+package piranhas
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+)
+
+// PathDiff describes a leaf, which differs between two values.
+// Old is nil, if the leaf only exists in the new value, and New is nil, if it only exists in the old value.
+type PathDiff struct {
+	Path string
+	Old  interface{}
+	New  interface{}
+}
+
+// Diff compares all leaves of a and b and returns the differences with their dotted paths.
+// Map entries and slice elements, which exist only in one of both values, are reported too,
+// while nil and empty slices and maps are equal.
+// The differences are ordered like the leaves of a, followed by the leaves only present in b.
+func Diff(a, b interface{}) ([]PathDiff, error) {
+	oldPaths, oldLeaves, err := collectLeaves(a)
+	if err != nil {
+		return nil, err
+	}
+	newPaths, newLeaves, err := collectLeaves(b)
+	if err != nil {
+		return nil, err
+	}
+
+	var diffs []PathDiff
+	for _, path := range oldPaths {
+		newLeaf, ok := newLeaves[path]
+		if !ok {
+			diffs = append(diffs, PathDiff{Path: path, Old: oldLeaves[path]})
+		} else if !equalLeaves(oldLeaves[path], newLeaf) {
+			diffs = append(diffs, PathDiff{Path: path, Old: oldLeaves[path], New: newLeaf})
+		}
+	}
+	for _, path := range newPaths {
+		if _, ok := oldLeaves[path]; !ok {
+			diffs = append(diffs, PathDiff{Path: path, New: newLeaves[path]})
+		}
+	}
+
+	return diffs, nil
+}
+
+// collectLeaves returns the dotted paths of all comparable leaves in walk order together with their values
+func collectLeaves(obj interface{}) ([]string, map[string]interface{}, error) {
+	var paths []string
+	leaves := make(map[string]interface{})
+
+	err := walkLeaves(reflect.ValueOf(obj), nil, make(map[visit]bool), func(pathelements []string, value reflect.Value) error {
+		leaf, err := getInterfaceOfValue(getUnexportedValue(value))
+		if err == errUnsupportedKind {
+			// channels and functions can not be compared
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		path := strings.Join(pathelements, ".")
+		paths = append(paths, path)
+		leaves[path] = leaf
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return paths, leaves, nil
+}
+
+// equalLeaves reports whether two leaves are equal, where a nil byte slice equals an empty one
+func equalLeaves(a, b interface{}) bool {
+	aBytes, aOk := a.([]byte)
+	bBytes, bOk := b.([]byte)
+	if aOk && bOk {
+		return bytes.Equal(aBytes, bBytes)
+	}
+
+	return reflect.DeepEqual(a, b)
+}
@@ -0,0 +1,154 @@
+package piranhas
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		name     string
+		change   func(p *person)
+		expected []PathDiff
+	}{
+		{
+			name:     "Equal",
+			change:   func(p *person) {},
+			expected: nil,
+		},
+		{
+			name:     "Nested field",
+			change:   func(p *person) { p.address.city = "Hamburg" },
+			expected: []PathDiff{{Path: "address.city", Old: "Berlin", New: "Hamburg"}},
+		},
+		{
+			name:     "Map entry",
+			change:   func(p *person) { p.hobbys["Skydiving"] = 3 },
+			expected: []PathDiff{{Path: "hobbys.Skydiving", Old: 9, New: 3}},
+		},
+		{
+			name:     "Added map entry",
+			change:   func(p *person) { p.hobbys["Chess"] = 5 },
+			expected: []PathDiff{{Path: "hobbys.Chess", Old: nil, New: 5}},
+		},
+		{
+			name:     "Removed map entry",
+			change:   func(p *person) { delete(p.hobbys, "Crochet") },
+			expected: []PathDiff{{Path: "hobbys.Crochet", Old: 0, New: nil}},
+		},
+		{
+			name:   "Shorter slice",
+			change: func(p *person) { p.adresses1 = p.adresses1[:1] },
+			expected: []PathDiff{
+				{Path: "adresses1.1.street", Old: "Kanzlerpaltz", New: nil},
+				{Path: "adresses1.1.number", Old: 1, New: nil},
+				{Path: "adresses1.1.city", Old: "Berlin", New: nil},
+				{Path: "adresses1.1.ZIP", Old: "10000", New: nil},
+			},
+		},
+		{
+			name: "Several fields",
+			change: func(p *person) {
+				p.age = 59
+				p.address.number = 30
+			},
+			expected: []PathDiff{
+				{Path: "age", Old: 58, New: 59},
+				{Path: "address.number", Old: 29, New: 30},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			a := buildPersonData()
+			b := buildPersonData()
+			test.change(b)
+
+			result, err := Diff(a, b)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(result, test.expected) {
+				t.Errorf("Expected %v, but got %v", test.expected, result)
+			}
+		})
+	}
+}
+
+type diffContainers struct {
+	numbers []int
+	hobbys  map[string]int
+	data    []byte
+}
+
+type diffSmallInts struct {
+	level   int8
+	address uintptr
+	levels  map[string]int8
+	handles map[string]uintptr
+}
+
+type diffNode struct {
+	name string
+	next *diffNode
+}
+
+func TestDiffNilAndEmpty(t *testing.T) {
+	a := &diffContainers{}
+	b := &diffContainers{numbers: []int{}, hobbys: map[string]int{}, data: []byte{}}
+
+	result, err := Diff(a, b)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result != nil {
+		t.Errorf("Expected no differences, but got %v", result)
+	}
+
+	b.numbers = append(b.numbers, 7)
+	result, err = Diff(a, b)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := []PathDiff{{Path: "numbers.0", Old: nil, New: 7}}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %v, but got %v", expected, result)
+	}
+}
+
+func TestDiffCycle(t *testing.T) {
+	build := func(name string) *diffNode {
+		first := &diffNode{name: "first"}
+		first.next = &diffNode{name: name, next: first}
+		return first
+	}
+
+	result, err := Diff(build("second"), build("last"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := []PathDiff{{Path: "next.name", Old: "second", New: "last"}}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %v, but got %v", expected, result)
+	}
+}
+
+func TestDiffSmallInts(t *testing.T) {
+	a := diffSmallInts{level: 1, address: 0x10, levels: map[string]int8{"a": 1}, handles: map[string]uintptr{"a": 0x10}}
+	b := diffSmallInts{level: 2, address: 0x20, levels: map[string]int8{"a": 3}, handles: map[string]uintptr{"a": 0x30}}
+
+	result, err := Diff(a, b)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := []PathDiff{
+		{Path: "level", Old: int8(1), New: int8(2)},
+		{Path: "address", Old: uintptr(0x10), New: uintptr(0x20)},
+		{Path: "levels.a", Old: int8(1), New: int8(3)},
+		{Path: "handles.a", Old: uintptr(0x10), New: uintptr(0x30)},
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %v, but got %v", expected, result)
+	}
+}
@@ -0,0 +1,82 @@
+package piranhas
+
+import (
+	"reflect"
+	"strings"
+)
+
+// EnvKeysForDefaults returns the name of the environment variable of every field with an env or default tag
+// mapped to its default value, so a .env template can be generated from the type of v.
+// The name is taken from the env tag, otherwise it is derived from the path of the field in UPPER_SNAKE notation,
+// like DATABASE_PORT for the field port of the struct field database. The prefix is put in front of all names
+// separated by '_'. Struct fields are passed through, even if they have an env tag, but fields below pointers to structs
+// are not listed, because these may be nil. These are the names read by SetDefaultsEnvPrefix with the same prefix.
+// Only the type of v is inspected, so v can also be a nil pointer to the struct.
+func EnvKeysForDefaults(v interface{}, prefix string) map[string]string {
+	prefix = envKeyPrefix(prefix)
+
+	keys := make(map[string]string)
+	objType := reflect.TypeOf(v)
+	if objType == nil {
+		return keys
+	}
+
+	collectEnvKeys(objType, nil, prefix, keys, make(map[reflect.Type]bool))
+	return keys
+}
+
+// envKeyPrefix returns the prefix separated by '_' from the names, which follow it
+func envKeyPrefix(prefix string) string {
+	if prefix != "" && !strings.HasSuffix(prefix, "_") {
+		prefix += "_"
+	}
+	return prefix
+}
+
+// collectEnvKeys adds the environment variables of the struct fields below objType to keys.
+// The types currently passed through are marked as visiting, so recursive types are not passed through endlessly.
+func collectEnvKeys(objType reflect.Type, names []string, prefix string, keys map[string]string, visiting map[reflect.Type]bool) {
+	// read all pointers away
+	for objType.Kind() == reflect.Ptr {
+		objType = objType.Elem()
+	}
+	if objType.Kind() != reflect.Struct || visiting[objType] {
+		return
+	}
+	visiting[objType] = true
+	defer delete(visiting, objType)
+
+	for i := 0; i < objType.NumField(); i++ {
+		field := objType.Field(i)
+		if field.Name == "_" {
+			continue
+		}
+		defaultTag, hasDefault := field.Tag.Lookup("default")
+		envTag := field.Tag.Get("env")
+
+		// the names are copied, so the appended name does not change the names of the siblings
+		fieldNames := append(names[:len(names):len(names)], toUpperSnake(field.Name))
+
+		fieldType := field.Type
+		for fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+
+		// structs are passed through except below pointers, time.Time and url.URL are set by their tags like scalars
+		if fieldType.Kind() == reflect.Struct && fieldType.String() != "time.Time" && fieldType.String() != "url.URL" {
+			if field.Type.Kind() != reflect.Ptr {
+				collectEnvKeys(fieldType, fieldNames, prefix, keys, visiting)
+			}
+			continue
+		}
+		if !hasDefault && envTag == "" {
+			continue
+		}
+
+		if envTag != "" {
+			keys[prefix+envTag] = defaultTag
+		} else {
+			keys[prefix+strings.Join(fieldNames, "_")] = defaultTag
+		}
+	}
+}
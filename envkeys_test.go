@@ -0,0 +1,138 @@
+package piranhas
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+type envKeysDatabase struct {
+	host     string `env:"DATABASE_HOST" default:"localhost"`
+	port     int    `default:"5432"`
+	password string `env:"DATABASE_PASSWORD"`
+	options  map[string]string
+}
+
+type envKeysConfig struct {
+	_        struct{}      `defaults:"piranhas-test-env"`
+	appName  string        `default:"piranhas"`
+	timeout  time.Duration `default:"30s"`
+	started  time.Time     `default:"2023-01-02T15:04:05Z"`
+	database envKeysDatabase
+	replica  *envKeysDatabase
+	parent   *envKeysConfig
+	comment  string
+}
+
+func TestEnvKeysForDefaults(t *testing.T) {
+	tests := []struct {
+		name     string
+		v        interface{}
+		prefix   string
+		expected map[string]string
+	}{
+		{
+			name:   "without prefix",
+			v:      envKeysConfig{},
+			prefix: "",
+			expected: map[string]string{
+				"APP_NAME":          "piranhas",
+				"TIMEOUT":           "30s",
+				"STARTED":           "2023-01-02T15:04:05Z",
+				"DATABASE_HOST":     "localhost",
+				"DATABASE_PORT":     "5432",
+				"DATABASE_PASSWORD": "",
+			},
+		},
+		{
+			name:   "with prefix of a nil pointer",
+			v:      (*envKeysDatabase)(nil),
+			prefix: "APP",
+			expected: map[string]string{
+				"APP_DATABASE_HOST":     "localhost",
+				"APP_PORT":              "5432",
+				"APP_DATABASE_PASSWORD": "",
+			},
+		},
+		{
+			name:     "no struct",
+			v:        42,
+			prefix:   "APP_",
+			expected: map[string]string{},
+		},
+		{
+			name:     "nil",
+			v:        nil,
+			prefix:   "APP_",
+			expected: map[string]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := EnvKeysForDefaults(tt.v, tt.prefix)
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("Expected %v, but got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+type envKeysServer struct {
+	name string `env:"SERVER_NAME" default:"web"`
+}
+
+type envKeysService struct {
+	appName  string        `default:"piranhas"`
+	timeout  time.Duration `default:"30s"`
+	database envKeysDatabase
+	primary  envKeysServer `env:"PRIMARY"`
+	replica  *envKeysDatabase
+	servers  []envKeysServer
+}
+
+func TestEnvKeysForDefaultsRoundTrip(t *testing.T) {
+	values := map[string]string{
+		"APP_APP_NAME":          "service",
+		"APP_TIMEOUT":           "1m",
+		"APP_DATABASE_HOST":     "db",
+		"APP_DATABASE_PORT":     "6543",
+		"APP_DATABASE_PASSWORD": "secret",
+		"APP_SERVER_NAME":       "api",
+	}
+
+	keys := EnvKeysForDefaults(&envKeysService{}, "APP")
+	if len(keys) != len(values) {
+		t.Fatalf("Expected keys %v, but got %v", values, keys)
+	}
+	for key := range keys {
+		value, ok := values[key]
+		if !ok {
+			t.Fatalf("Unexpected key %s", key)
+		}
+		t.Setenv(key, value)
+	}
+
+	// the names without prefix, the env tags of struct fields and the names below pointers and slices are not read
+	t.Setenv("DATABASE_HOST", "unused")
+	t.Setenv("SERVER_NAME", "unused")
+	t.Setenv("APP_PRIMARY", "unused")
+	t.Setenv("APP_REPLICA_PORT", "1234")
+
+	result := &envKeysService{replica: &envKeysDatabase{}, servers: []envKeysServer{{}}}
+	if err := SetDefaultsEnvPrefix(result, "APP"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := &envKeysService{
+		appName:  "service",
+		timeout:  time.Minute,
+		database: envKeysDatabase{host: "db", port: 6543, password: "secret"},
+		primary:  envKeysServer{name: "api"},
+		replica:  &envKeysDatabase{host: "localhost", port: 5432},
+		servers:  []envKeysServer{{name: "web"}},
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %+v, but got %+v", expected, result)
+	}
+}
@@ -0,0 +1,176 @@
+package piranhas
+
+import (
+	"errors"
+	"strconv"
+	"unicode"
+)
+
+var (
+	errUnknownReference = errors.New("unknown field reference")
+	errNoNumericField   = errors.New("referenced field is not numeric")
+	errDivisionByZero   = errors.New("division by zero")
+	errExpression       = errors.New("invalid expression")
+)
+
+// exprParser is a recursive descent parser for simple arithmetic expressions.
+// It only knows numbers, field references, parentheses and the operators + - * /, so no arbitrary code can be executed.
+type exprParser struct {
+	expr   []rune
+	pos    int
+	lookup func(name string) (float64, error)
+}
+
+// evalExpression evaluates the expression and resolves field references with the lookup function
+func evalExpression(expr string, lookup func(name string) (float64, error)) (float64, error) {
+	p := &exprParser{expr: []rune(expr), lookup: lookup}
+
+	result, err := p.parseSum()
+	if err != nil {
+		return 0, err
+	}
+
+	// the whole expression must be consumed
+	p.skipSpaces()
+	if p.pos < len(p.expr) {
+		return 0, errExpression
+	}
+
+	return result, nil
+}
+
+// parseSum parses terms separated by + and -
+func (p *exprParser) parseSum() (float64, error) {
+	result, err := p.parseProduct()
+	if err != nil {
+		return 0, err
+	}
+
+	for {
+		p.skipSpaces()
+		if p.pos >= len(p.expr) {
+			return result, nil
+		}
+
+		switch p.expr[p.pos] {
+		case '+':
+			p.pos++
+			operand, err := p.parseProduct()
+			if err != nil {
+				return 0, err
+			}
+			result += operand
+
+		case '-':
+			p.pos++
+			operand, err := p.parseProduct()
+			if err != nil {
+				return 0, err
+			}
+			result -= operand
+
+		default:
+			return result, nil
+		}
+	}
+}
+
+// parseProduct parses factors separated by * and /
+func (p *exprParser) parseProduct() (float64, error) {
+	result, err := p.parseFactor()
+	if err != nil {
+		return 0, err
+	}
+
+	for {
+		p.skipSpaces()
+		if p.pos >= len(p.expr) {
+			return result, nil
+		}
+
+		switch p.expr[p.pos] {
+		case '*':
+			p.pos++
+			operand, err := p.parseFactor()
+			if err != nil {
+				return 0, err
+			}
+			result *= operand
+
+		case '/':
+			p.pos++
+			operand, err := p.parseFactor()
+			if err != nil {
+				return 0, err
+			}
+			if operand == 0 {
+				return 0, errDivisionByZero
+			}
+			result /= operand
+
+		default:
+			return result, nil
+		}
+	}
+}
+
+// parseFactor parses a number, a field reference, a signed factor or an expression in parentheses
+func (p *exprParser) parseFactor() (float64, error) {
+	p.skipSpaces()
+	if p.pos >= len(p.expr) {
+		return 0, errExpression
+	}
+
+	c := p.expr[p.pos]
+	switch {
+	case c == '(':
+		p.pos++
+		result, err := p.parseSum()
+		if err != nil {
+			return 0, err
+		}
+		p.skipSpaces()
+		if p.pos >= len(p.expr) || p.expr[p.pos] != ')' {
+			return 0, errExpression
+		}
+		p.pos++
+		return result, nil
+
+	case c == '-':
+		p.pos++
+		result, err := p.parseFactor()
+		return -result, err
+
+	case c == '+':
+		p.pos++
+		return p.parseFactor()
+
+	case unicode.IsDigit(c) || c == '.':
+		start := p.pos
+		for p.pos < len(p.expr) && (unicode.IsDigit(p.expr[p.pos]) || p.expr[p.pos] == '.') {
+			p.pos++
+		}
+		result, err := strconv.ParseFloat(string(p.expr[start:p.pos]), 64)
+		if err != nil {
+			return 0, errExpression
+		}
+		return result, nil
+
+	case unicode.IsLetter(c) || c == '_':
+		start := p.pos
+		for p.pos < len(p.expr) && (unicode.IsLetter(p.expr[p.pos]) || unicode.IsDigit(p.expr[p.pos]) || p.expr[p.pos] == '_') {
+			p.pos++
+		}
+		return p.lookup(string(p.expr[start:p.pos]))
+
+	default:
+		return 0, errExpression
+	}
+}
+
+// skipSpaces moves the position behind all space characters
+func (p *exprParser) skipSpaces() {
+	for p.pos < len(p.expr) && unicode.IsSpace(p.expr[p.pos]) {
+		p.pos++
+	}
+}
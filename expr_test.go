@@ -0,0 +1,42 @@
+package piranhas
+
+import (
+	"testing"
+)
+
+func TestEvalExpression(t *testing.T) {
+	fields := map[string]float64{"width": 20, "height": 5}
+	lookup := func(name string) (float64, error) {
+		value, ok := fields[name]
+		if !ok {
+			return 0, errUnknownReference
+		}
+		return value, nil
+	}
+
+	tests := []struct {
+		expr     string
+		expected float64
+		err      error
+	}{
+		{expr: "1+2*3", expected: 7, err: nil},
+		{expr: "(1+2)*3", expected: 9, err: nil},
+		{expr: "width - height / 5", expected: 19, err: nil},
+		{expr: "-width + 2.5", expected: -17.5, err: nil},
+		{expr: "width * depth", expected: 0, err: errUnknownReference},
+		{expr: "width / 0", expected: 0, err: errDivisionByZero},
+		{expr: "width +", expected: 0, err: errExpression},
+		{expr: "(width", expected: 0, err: errExpression},
+		{expr: "width; height", expected: 0, err: errExpression},
+	}
+
+	for _, test := range tests {
+		result, err := evalExpression(test.expr, lookup)
+		if err != test.err {
+			t.Errorf("For %s expected error: %v, but got: %v", test.expr, test.err, err)
+		}
+		if result != test.expected {
+			t.Errorf("For %s expected %v, but got %v", test.expr, test.expected, result)
+		}
+	}
+}
@@ -1,9 +1,12 @@
 package piranhas
 
 import (
+	"database/sql/driver"
 	"errors"
 	"fmt"
+	"os"
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -26,10 +29,54 @@ var (
 	errEscape2Chars                      = errors.New("escape mode requires a second character")
 	errSpaceInElement                    = errors.New("space characters are not permitted in element")
 	errEndQuotsOpen                      = errors.New("element ends without quotes being closed")
+	errNoContainer                       = errors.New("element is not a slice, array or map")
+	errNoMap                             = errors.New("element is not a map")
+	errNoSlice                           = errors.New("element is not a slice or array")
+	errNoChannel                         = errors.New("element is not a receivable channel")
+	errNoStructField                     = errors.New("element is not a struct field")
+	errNoStruct                          = errors.New("element is not a struct")
+	errUnsupportedKind                   = errors.New("element is a channel, function or unsafe pointer, which is not supported")
+	errOutOfRange                        = errors.New("range is outside of the element")
+	errUnsupportedMapKey                 = errors.New("unsupported key type")
+	errAmbiguousField                    = errors.New("field name is ambiguous between a direct and an embedded field")
+	errRefCycle                          = errors.New("references form a cycle")
+	errArrayLength                       = errors.New("byte array has a different length")
 )
 
-// parsePath parses a given path string and returns a slice of path elements
+// Indexable is implemented by collections, which can not be indexed by reflection, like paginated results.
+// A numeric path element on a value implementing Indexable is resolved by its Index method.
+type Indexable interface {
+	Len() int
+	Index(i int) interface{}
+}
+
+// Optional is implemented by wrappers like Option[T], which may or may not hold a value.
+// While resolving a path, a present Optional is replaced by its value and an absent one is treated like nil.
+type Optional interface {
+	Get() (interface{}, bool)
+}
+
+// parsePath parses a given path string and returns a slice of path elements.
+// Parsed paths are cached, so the returned slice is shared and must not be changed.
 func parsePath(path string) ([]string, error) {
+	if pathelements, ok := parsedPaths.get(path); ok {
+		return pathelements, nil
+	}
+
+	pathelements, err := parsePathElements(path)
+	if err != nil {
+		return nil, err
+	}
+
+	// appending to the cached path elements must not change them
+	pathelements = pathelements[:len(pathelements):len(pathelements)]
+	parsedPaths.put(path, pathelements)
+
+	return pathelements, nil
+}
+
+// parsePathElements parses a given path string into a slice of path elements without using the cache
+func parsePathElements(path string) ([]string, error) {
 	// trim common prefixes and replace slashes/backslashes with dots
 	path = strings.TrimSpace(path)
 	path = strings.TrimPrefix(path, "$..")
@@ -180,79 +227,157 @@ func parsePath(path string) ([]string, error) {
 // It traverses through the path elements, handling pointers, and extracts the requested value from the reflect.Value.
 // It returns the extracted value or an error if the path is too long or if the value is not found.
 func returnPathElement(objValue reflect.Value, pathelements []string) (interface{}, error) {
-	// read all pointers away
+	elemValue, err := getPathValue(objValue, pathelements)
+	if err != nil {
+		return nil, err
+	}
+
+	return getInterfaceOfValue(elemValue)
+}
+
+// pathOptions controls how the path elements are resolved
+type pathOptions struct {
+	// jsonTags resolves struct fields by the names of their json tags instead of the field names
+	jsonTags bool
+
+	// strict rejects field names, which exist in the struct as well as in one of its embedded structs
+	strict bool
+}
+
+// getPathValue traverses through the path elements and returns the reflect.Value addressed by them.
+// A nil pointer at the end of the path is returned as it is, a nil pointer within the path results in errPathToLong.
+func getPathValue(objValue reflect.Value, pathelements []string) (reflect.Value, error) {
+	return getPathValueWith(objValue, pathelements, pathOptions{})
+}
+
+// getPathValueWith traverses through the path elements like getPathValue with the given options
+func getPathValueWith(objValue reflect.Value, pathelements []string, opts pathOptions) (reflect.Value, error) {
+	// read all pointers and interfaces away
 	for {
-		if objValue.Kind() == reflect.Ptr {
+		if objValue.Kind() == reflect.Ptr || objValue.Kind() == reflect.Interface {
 			if objValue.IsNil() {
-				// if there are no more path elements, return nil (value not found)
+				// if there are no more path elements, return the nil value (value not found)
 				if len(pathelements) == 0 {
-					return nil, nil
+					return objValue, nil
 				}
 				// otherwise, return an error (path is too long)
-				return nil, errPathToLong
+				return reflect.Value{}, errPathToLong
 			}
 			objValue = objValue.Elem()
 		} else {
-			// break the loop if objValue is not a pointer
+			// break the loop if objValue is neither a pointer nor an interface
 			break
 		}
 	}
 
-	// if there are no more path elements, return the interface representation of value
+	// a present Optional is unwrapped to its value, an absent one is treated like a nil pointer
+	if optional, ok := getOptional(objValue); ok {
+		value, present := optional.Get()
+		if !present || value == nil {
+			if len(pathelements) == 0 {
+				return reflect.Value{}, nil
+			}
+			return reflect.Value{}, errPathToLong
+		}
+		return getPathValueWith(reflect.ValueOf(value), pathelements, opts)
+	}
+
+	// if there are no more path elements, the value is found
 	if len(pathelements) == 0 {
-		return getInterfaceOfValue(objValue)
+		return objValue, nil
+	}
+
+	// collections, which can not be indexed by reflection, are indexed through the Indexable interface,
+	// which is only looked up for numeric path elements
+	if kind := objValue.Kind(); kind != reflect.Slice && kind != reflect.Array && kind != reflect.Map {
+		if index, err := strconv.Atoi(pathelements[0]); err == nil {
+			if indexable, ok := getIndexable(objValue); ok {
+				if index < 0 || index >= indexable.Len() {
+					return reflect.Value{}, errObjNotExists
+				}
+				return getPathValueWith(reflect.ValueOf(indexable.Index(index)), pathelements[1:], opts)
+			}
+		}
 	}
 
 	// process the objValue based on its kind
 	switch objValue.Kind() {
 	case reflect.Struct, reflect.Slice, reflect.Array, reflect.Map:
-		return getPathContainer(objValue, pathelements)
+		elemValue, err := getPathContainer(objValue, pathelements[0], opts)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+
+		// deepen with the remaining path elements
+		return getPathValueWith(elemValue, pathelements[1:], opts)
 
 	default:
-		return nil, errPathToLong
+		return reflect.Value{}, errPathToLong
 	}
 }
 
-// getPathContainer retrieves the container for a given path in the project
-// It takes a path string as input and returns the corresponding container object
-func getPathContainer(objValue reflect.Value, pathelements []string) (interface{}, error) {
-	// check input
-	if len(pathelements) == 0 {
-		return nil, errPathToShort
+// getOptional returns the Optional implemented by the value or by a pointer to it
+func getOptional(objValue reflect.Value) (Optional, bool) {
+	if !objValue.IsValid() {
+		return nil, false
 	}
 
-	// read all pointers away
-	for {
-		if objValue.Kind() == reflect.Ptr {
-			if objValue.IsNil() {
-				// if there are no more path elements, return nil (value not found)
-				if len(pathelements) > 0 {
-					return nil, nil
-				}
-				// otherwise, return an error (path is too long)
-				return nil, errPathToLong
-			}
-			objValue = objValue.Elem()
-		} else {
-			// break the loop if objValue is not a pointer
-			break
+	objValue = getUnexportedValue(objValue)
+	if objValue.CanAddr() {
+		if optional, ok := objValue.Addr().Interface().(Optional); ok {
+			return optional, true
+		}
+	}
+	if objValue.CanInterface() {
+		optional, ok := objValue.Interface().(Optional)
+		return optional, ok
+	}
+
+	return nil, false
+}
+
+// getIndexable returns the Indexable implemented by the value or by a pointer to it
+func getIndexable(objValue reflect.Value) (Indexable, bool) {
+	objValue = getUnexportedValue(objValue)
+	if objValue.CanAddr() {
+		if indexable, ok := objValue.Addr().Interface().(Indexable); ok {
+			return indexable, true
 		}
 	}
+	if objValue.CanInterface() {
+		indexable, ok := objValue.Interface().(Indexable)
+		return indexable, ok
+	}
+
+	return nil, false
+}
 
+// getPathContainer retrieves the element of a struct, slice, array or map addressed by a single path element
+func getPathContainer(objValue reflect.Value, pathelement string, opts pathOptions) (reflect.Value, error) {
 	var elemValue reflect.Value
 	switch objValue.Kind() {
 	case reflect.Struct:
 		// search the specific field
-		elemValue = objValue.FieldByName(pathelements[0])
+		if opts.jsonTags {
+			elemValue = getJSONField(objValue, pathelement)
+		} else {
+			if opts.strict && countFields(objValue.Type(), pathelement, make(map[reflect.Type]bool)) > 1 {
+				return reflect.Value{}, errAmbiguousField
+			}
+			elemValue = objValue.FieldByName(pathelement)
+			if !elemValue.IsValid() {
+				elemValue = getPromotedField(objValue, pathelement)
+			}
+		}
 		if !elemValue.IsValid() {
-			return nil, errObjNotExists
+			return reflect.Value{}, errObjNotExists
 		}
 
 	case reflect.Slice, reflect.Array:
 		// determine and check the index
-		index, err := strconv.Atoi(pathelements[0])
+		index, err := strconv.Atoi(pathelement)
 		if err != nil || index < 0 || index >= objValue.Len() {
-			return nil, errObjNotExists
+			return reflect.Value{}, errObjNotExists
 		}
 
 		// array element to index determine
@@ -260,71 +385,199 @@ func getPathContainer(objValue reflect.Value, pathelements []string) (interface{
 
 	case reflect.Map:
 		// determine the value for the key
-		keyType := objValue.Type().Key()
-		switch keyType.Kind() {
-		case reflect.String:
-			elemValue = objValue.MapIndex(reflect.ValueOf(pathelements[0]))
+		key, err := parseMapKey(pathelement, objValue.Type().Key())
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		// the values of a map of an unexported field can only be read, if the map is made readable first
+		elemValue = getUnexportedValue(objValue).MapIndex(key)
+		if !elemValue.IsValid() {
+			return reflect.Value{}, errObjNotExists
+		}
 
-		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-			key, err := strconv.ParseInt(pathelements[0], 10, keyType.Bits())
-			if err != nil {
-				return reflect.Value{}, errObjNotExists
+	default:
+		return reflect.Value{}, errWrongElementType
+	}
+
+	return elemValue, nil
+}
+
+// parseMapKey converts a path element into a value of the key type of a map.
+// If the path element can not be converted, the key can not exist and errObjNotExists is returned.
+func parseMapKey(pathelement string, keyType reflect.Type) (reflect.Value, error) {
+	switch keyType.Kind() {
+	case reflect.String:
+		return reflect.ValueOf(pathelement).Convert(keyType), nil
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		key, err := strconv.ParseInt(pathelement, 10, keyType.Bits())
+		if err != nil {
+			return reflect.Value{}, errObjNotExists
+		}
+		return reflect.ValueOf(key).Convert(keyType), nil
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		key, err := strconv.ParseUint(pathelement, 10, keyType.Bits())
+		if err != nil {
+			return reflect.Value{}, errObjNotExists
+		}
+		return reflect.ValueOf(key).Convert(keyType), nil
+
+	case reflect.Float32, reflect.Float64:
+		key, err := strconv.ParseFloat(pathelement, keyType.Bits())
+		if err != nil {
+			return reflect.Value{}, errObjNotExists
+		}
+		return reflect.ValueOf(key).Convert(keyType), nil
+
+	case reflect.Bool:
+		key, err := strconv.ParseBool(pathelement)
+		if err != nil {
+			return reflect.Value{}, errObjNotExists
+		}
+		return reflect.ValueOf(key).Convert(keyType), nil
+
+	default:
+		// keys like pointers or structs can not be written as a path element
+		return reflect.Value{}, fmt.Errorf("%w: %s", errUnsupportedMapKey, keyType)
+	}
+}
+
+// getPromotedField searches a field in the concrete values of embedded interfaces,
+// an index in embedded slice and array types and a key in embedded map types. These are not promoted by reflect.
+func getPromotedField(objValue reflect.Value, pathelement string) reflect.Value {
+	for i := 0; i < objValue.NumField(); i++ {
+		if !objValue.Type().Field(i).Anonymous {
+			continue
+		}
+
+		// read all pointers and interfaces away
+		fieldValue := objValue.Field(i)
+		for (fieldValue.Kind() == reflect.Ptr || fieldValue.Kind() == reflect.Interface) && !fieldValue.IsNil() {
+			fieldValue = fieldValue.Elem()
+		}
+		switch fieldValue.Kind() {
+		case reflect.Struct:
+			// search the field in the embedded struct and its embedded interfaces
+			elemValue := fieldValue.FieldByName(pathelement)
+			if !elemValue.IsValid() {
+				elemValue = getPromotedField(fieldValue, pathelement)
+			}
+			if elemValue.IsValid() {
+				return elemValue
 			}
-			elemValue = objValue.MapIndex(reflect.ValueOf(key).Convert(keyType))
 
-		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-			key, err := strconv.ParseUint(pathelements[0], 10, keyType.Bits())
-			if err != nil {
-				return reflect.Value{}, errObjNotExists
+		case reflect.Slice, reflect.Array:
+			// an embedded slice type promotes its elements by index
+			if elemValue, err := getPathContainer(fieldValue, pathelement, pathOptions{}); err == nil {
+				return elemValue
 			}
-			elemValue = objValue.MapIndex(reflect.ValueOf(key).Convert(keyType))
 
-		case reflect.Float32, reflect.Float64:
-			key, err := strconv.ParseFloat(pathelements[0], keyType.Bits())
-			if err != nil {
-				return reflect.Value{}, errObjNotExists
+		case reflect.Map:
+			// an embedded map type promotes its values by key
+			if elemValue, err := getPathContainer(fieldValue, pathelement, pathOptions{}); err == nil {
+				return elemValue
 			}
-			elemValue = objValue.MapIndex(reflect.ValueOf(key).Convert(keyType))
+		}
+	}
 
-		case reflect.Bool:
-			key, err := strconv.ParseBool(pathelements[0])
-			if err != nil {
-				return reflect.Value{}, errObjNotExists
+	return reflect.Value{}
+}
+
+// countFields counts the fields with the name in the struct type and in all of its embedded structs
+func countFields(objType reflect.Type, name string, visited map[reflect.Type]bool) int {
+	// embedded pointers can lead to the same type again
+	if visited[objType] {
+		return 0
+	}
+	visited[objType] = true
+
+	count := 0
+	for i := 0; i < objType.NumField(); i++ {
+		field := objType.Field(i)
+		if field.Name == name {
+			count++
+		}
+		if field.Anonymous {
+			fieldType := field.Type
+			for fieldType.Kind() == reflect.Ptr {
+				fieldType = fieldType.Elem()
+			}
+			if fieldType.Kind() == reflect.Struct {
+				count += countFields(fieldType, name, visited)
 			}
-			elemValue = objValue.MapIndex(reflect.ValueOf(key))
+		}
+	}
 
-		default:
-			return reflect.Value{}, fmt.Errorf("unsupported key type: %s", keyType.Kind())
+	return count
+}
+
+// getJSONField searches the field, which encoding/json would use for the name.
+// Fields without a json tag are matched by their name ignoring the case, and the fields of
+// embedded structs without a json tag are promoted. Fields tagged with "-" and unexported fields are never matched.
+func getJSONField(objValue reflect.Value, name string) reflect.Value {
+	for i := 0; i < objValue.NumField(); i++ {
+		field := objValue.Type().Field(i)
+		jsonTag := field.Tag.Get("json")
+		if jsonTag == "-" || isJSONIgnored(field) {
+			continue
 		}
-		if !elemValue.IsValid() {
-			return nil, errObjNotExists
+
+		tagName, _, _ := strings.Cut(jsonTag, ",")
+		if tagName == name || (tagName == "" && !field.Anonymous && strings.EqualFold(field.Name, name)) {
+			return objValue.Field(i)
 		}
+	}
 
-	default:
-		return nil, errWrongElementType
+	// search in embedded structs after the direct fields, because these take precedence
+	for i := 0; i < objValue.NumField(); i++ {
+		field := objValue.Type().Field(i)
+		if !field.Anonymous || field.Tag.Get("json") != "" || isJSONIgnored(field) {
+			continue
+		}
+
+		// read all pointers away
+		fieldValue := objValue.Field(i)
+		for fieldValue.Kind() == reflect.Ptr && !fieldValue.IsNil() {
+			fieldValue = fieldValue.Elem()
+		}
+		if fieldValue.Kind() == reflect.Struct {
+			if elemValue := getJSONField(fieldValue, name); elemValue.IsValid() {
+				return elemValue
+			}
+		}
 	}
 
-	// if there are more pathelements, then deepen, otherwise return this interface{}
-	if len(pathelements) > 1 {
-		return returnPathElement(elemValue, pathelements[1:])
+	return reflect.Value{}
+}
+
+// isJSONIgnored reports whether encoding/json ignores the field, because it is unexported.
+// Embedded structs are an exception, as their exported fields are promoted even if the struct type is unexported.
+func isJSONIgnored(field reflect.StructField) bool {
+	if field.Anonymous {
+		fieldType := field.Type
+		if fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+		return !field.IsExported() && fieldType.Kind() != reflect.Struct
 	}
 
-	return getInterfaceOfValue(elemValue)
+	return !field.IsExported()
 }
 
 // getInterfaceOfValue takes a reflect.Value and returns its corresponding interface{} value
-// If the value is a function without parameters, it returns the function itself
+// Channels, functions and unsafe pointers are not returned, for them errUnsupportedKind is reported
 // For other types, it attempts to convert the value to an interface{}
 func getInterfaceOfValue(objValue reflect.Value) (interface{}, error) {
-	// read all pointers away
+	// read all pointers and interfaces away
 	for {
-		if objValue.Kind() == reflect.Ptr {
+		if objValue.Kind() == reflect.Ptr || objValue.Kind() == reflect.Interface {
 			if objValue.IsNil() {
 				return nil, nil
 			}
 			objValue = objValue.Elem()
 		} else {
-			// break the loop if objValue is not a pointer
+			// break the loop if objValue is neither a pointer nor an interface
 			break
 		}
 	}
@@ -341,8 +594,17 @@ func getInterfaceOfValue(objValue reflect.Value) (interface{}, error) {
 		return objValue.Bool(), nil
 
 	case reflect.Int:
+		switch objValue.Type().String() {
+		case "time.Month":
+			return time.Month(objValue.Int()), nil
+		case "time.Weekday":
+			return time.Weekday(objValue.Int()), nil
+		}
 		return int(objValue.Int()), nil
 
+	case reflect.Int8:
+		return int8(objValue.Int()), nil
+
 	case reflect.Int16:
 		return int16(objValue.Int()), nil
 
@@ -370,6 +632,9 @@ func getInterfaceOfValue(objValue reflect.Value) (interface{}, error) {
 	case reflect.Uint64:
 		return uint64(objValue.Uint()), nil
 
+	case reflect.Uintptr:
+		return uintptr(objValue.Uint()), nil
+
 	case reflect.Float32:
 		return float32(objValue.Float()), nil
 
@@ -383,7 +648,16 @@ func getInterfaceOfValue(objValue reflect.Value) (interface{}, error) {
 		return complex128(objValue.Complex()), nil
 
 	case reflect.Struct:
-		if objValue.Type().String() == "time.Time" {
+		// the wrapper types of sync/atomic surface the value returned by their Load method
+		if isAtomicType(objValue.Type()) {
+			objValue = getUnexportedValue(objValue)
+			if !objValue.CanAddr() || !objValue.CanInterface() {
+				return nil, errIsNotInterfaceable
+			}
+			return getInterfaceOfValue(objValue.Addr().MethodByName("Load").Call(nil)[0])
+		}
+
+		if isTimeType(objValue.Type()) {
 			// get internal variables of time.Time
 			wall := uint64(objValue.FieldByName("wall").Uint())
 			ext := int64(objValue.FieldByName("ext").Int())
@@ -403,6 +677,22 @@ func getInterfaceOfValue(objValue reflect.Value) (interface{}, error) {
 			return objValue.Bytes(), nil
 		}
 
+	case reflect.Array:
+		// [N]byte like a UUID or a hash is returned as a copy of its bytes like a byte slice
+		if objValue.Type().Elem().Kind() == reflect.Uint8 {
+			bytes := make([]byte, objValue.Len())
+			for i := range bytes {
+				bytes[i] = byte(objValue.Index(i).Uint())
+			}
+			return bytes, nil
+		}
+
+		// arrays of unexported fields are read from their memory, so they can be interfaced
+		objValue = getUnexportedValue(objValue)
+
+	case reflect.Chan, reflect.Func, reflect.UnsafePointer:
+		// these kinds do not represent data, so they are rejected predictably
+		return nil, errUnsupportedKind
 	}
 
 	// for everything that has not been dealt with up to this point
@@ -413,144 +703,902 @@ func getInterfaceOfValue(objValue reflect.Value) (interface{}, error) {
 	}
 }
 
-// GetPathInterface retrieves the interface for a given path in the project
-func GetPathInterface(obj interface{}, path string) (interface{}, error) {
+// getPathReflectValue parses the path and returns the reflect.Value addressed by it
+func getPathReflectValue(obj interface{}, path string) (reflect.Value, error) {
 	// convert the path into a list of path elements
 	pathelements, err := parsePath(path)
 	if err != nil {
-		return nil, err
+		return reflect.Value{}, err
 	}
 
-	return returnPathElement(reflect.ValueOf(obj), pathelements)
+	return getPathValue(reflect.ValueOf(obj), pathelements)
 }
 
-// GetPathString returns the object addressed by the path as string
-func GetPathString(ptr interface{}, path string) (string, error) {
-	obj, err := GetPathInterface(ptr, path)
+// GetPathResult retrieves the interface for a given path and reports separately whether the path exists.
+// A value, which is present but nil like a nil pointer, is returned as nil with found set,
+// a missing struct field, index or map key is returned with found unset and without error.
+// The error is reserved for malformed paths and paths, which can not be resolved structurally.
+func GetPathResult(ptr interface{}, path string) (value interface{}, found bool, err error) {
+	// convert the path into a list of path elements
+	pathelements, err := parsePath(path)
 	if err != nil {
-		return "", err
+		return nil, false, err
 	}
-	if obj == nil {
-		return "", errObjNotExists
+
+	objValue, err := getPathValue(reflect.ValueOf(ptr), pathelements)
+	if err == errObjNotExists {
+		return nil, false, nil
 	}
-	sobj, ok := obj.(string)
-	if ok {
-		return sobj, nil
+	if err != nil {
+		return nil, false, err
 	}
 
-	return "", errors.New("object is not a string")
-}
-
-// GetPathBool returns the object addressed by the path as bool
-func GetPathBool(ptr interface{}, path string) (bool, error) {
-	obj, err := GetPathInterface(ptr, path)
+	value, err = getInterfaceOfValue(objValue)
 	if err != nil {
-		return false, err
-	}
-	if obj == nil {
-		return false, errObjNotExists
-	}
-	bobj, ok := obj.(bool)
-	if ok {
-		return bobj, nil
+		return nil, false, err
 	}
 
-	return false, errors.New("object is not a bool")
+	return value, true, nil
 }
 
-// GetPathInt returns the object addressed by the path as int
-func GetPathInt(ptr interface{}, path string) (int, error) {
-	obj, err := GetPathInterface(ptr, path)
+// GetPathInterface retrieves the interface for a given path in the project
+func GetPathInterface(obj interface{}, path string) (interface{}, error) {
+	value, found, err := GetPathResult(obj, path)
 	if err != nil {
-		return 0, err
-	}
-	if obj == nil {
-		return 0, errObjNotExists
+		return nil, err
 	}
-	iobj, ok := obj.(int)
-	if ok {
-		return iobj, nil
+	if !found {
+		return nil, errObjNotExists
 	}
 
-	return 0, errors.New("object is not a int")
+	return value, nil
 }
 
-// GetPathInt16 returns the object addressed by the path as int16
-func GetPathInt16(ptr interface{}, path string) (int16, error) {
-	obj, err := GetPathInterface(ptr, path)
+// GetJSON retrieves the interface for a path, which addresses the struct fields by the names of their json tags.
+// So the path mirrors the way the JSON document would be addressed, regardless of the field names.
+func GetJSON(obj interface{}, jsonPath string) (interface{}, error) {
+	// convert the path into a list of path elements
+	pathelements, err := parsePath(jsonPath)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
 
-	if obj == nil {
-		return 0, errObjNotExists
-	}
-	iobj, ok := obj.(int16)
-	if ok {
-		return iobj, nil
+	elemValue, err := getPathValueWith(reflect.ValueOf(obj), pathelements, pathOptions{jsonTags: true})
+	if err != nil {
+		return nil, err
 	}
 
-	return 0, errors.New("object is not a int16")
+	return getInterfaceOfValue(elemValue)
 }
 
-// GetPathInt32 returns the object addressed by the path as int32
-func GetPathInt32(ptr interface{}, path string) (int32, error) {
-	obj, err := GetPathInterface(ptr, path)
+// GetPathInterfaceStrict retrieves the interface for a given path like GetPathInterface, but a field name,
+// which exists in a struct as well as in one of its embedded structs, results in errAmbiguousField
+// instead of silently using the shallowest field
+func GetPathInterfaceStrict(obj interface{}, path string) (interface{}, error) {
+	// convert the path into a list of path elements
+	pathelements, err := parsePath(path)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
 
-	if obj == nil {
-		return 0, errObjNotExists
-	}
-	iobj, ok := obj.(int32)
-	if ok {
-		return iobj, nil
+	elemValue, err := getPathValueWith(reflect.ValueOf(obj), pathelements, pathOptions{strict: true})
+	if err != nil {
+		return nil, err
 	}
 
-	return 0, errors.New("object is not a int32")
+	return getInterfaceOfValue(elemValue)
 }
 
-// GetPathInt64 returns the object addressed by the path as int64
-func GetPathInt64(ptr interface{}, path string) (int64, error) {
-	obj, err := GetPathInterface(ptr, path)
+// GetPathInterfaceTyped retrieves the interface for a given path like GetPathInterface,
+// but defined types like `type Celsius float64` are preserved instead of being normalized to their kind
+func GetPathInterfaceTyped(obj interface{}, path string) (interface{}, error) {
+	objValue, err := getPathReflectValue(obj, path)
 	if err != nil {
-		return 0, err
-	}
-	if obj == nil {
-		return 0, errObjNotExists
+		return nil, err
 	}
-	iobj, ok := obj.(int64)
-	if ok {
-		return iobj, nil
+
+	// read all pointers and interfaces away
+	for objValue.Kind() == reflect.Ptr || objValue.Kind() == reflect.Interface {
+		if objValue.IsNil() {
+			return nil, nil
+		}
+		objValue = objValue.Elem()
 	}
 
-	return 0, errors.New("object is not a int64")
-}
+	switch objValue.Kind() {
+	case reflect.Invalid:
+		return nil, nil
 
-// GetPathUint returns the object addressed by the path as uint
-func GetPathUint(ptr interface{}, path string) (uint, error) {
-	obj, err := GetPathInterface(ptr, path)
-	if err != nil {
-		return 0, err
-	}
-	if obj == nil {
-		return 0, errObjNotExists
+	case reflect.Chan, reflect.Func, reflect.UnsafePointer:
+		return nil, errUnsupportedKind
+
+	case reflect.Bool, reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128:
+		// scalar values are copied into a new value of the same type, which works for map values too
+		copyValue := reflect.New(objValue.Type()).Elem()
+		switch objValue.Kind() {
+		case reflect.Bool:
+			copyValue.SetBool(objValue.Bool())
+		case reflect.String:
+			copyValue.SetString(objValue.String())
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			copyValue.SetInt(objValue.Int())
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			copyValue.SetUint(objValue.Uint())
+		case reflect.Float32, reflect.Float64:
+			copyValue.SetFloat(objValue.Float())
+		case reflect.Complex64, reflect.Complex128:
+			copyValue.SetComplex(objValue.Complex())
+		}
+		return copyValue.Interface(), nil
 	}
-	iobj, ok := obj.(uint)
-	if ok {
-		return iobj, nil
+
+	// everything else is read from the memory of unexported fields
+	objValue = getUnexportedValue(objValue)
+	if !objValue.CanInterface() {
+		return nil, errIsNotInterfaceable
 	}
 
-	return 0, errors.New("object is not a uint")
+	return objValue.Interface(), nil
 }
 
-// GetPathUint8 returns the object addressed by the path as uint8
-func GetPathUint8(ptr interface{}, path string) (uint8, error) {
-	obj, err := GetPathInterface(ptr, path)
-	if err != nil {
-		return 0, err
-	}
+// GetPathCoalesce returns the value of the first path, which exists and is not nil.
+// Malformed paths are reported as error, if none of the paths resolves errObjNotExists is returned.
+func GetPathCoalesce(ptr interface{}, paths ...string) (interface{}, error) {
+	for _, path := range paths {
+		// convert the path into a list of path elements
+		pathelements, err := parsePath(path)
+		if err != nil {
+			return nil, err
+		}
+
+		// missing elements are not an error, the next path is tried
+		obj, err := returnPathElement(reflect.ValueOf(ptr), pathelements)
+		if err == nil && obj != nil {
+			return obj, nil
+		}
+	}
+
+	return nil, errObjNotExists
+}
+
+// GetPathIndexPath returns the index sequence of the struct field addressed by the path.
+// The result is determined by the types only and can be cached and passed to reflect.Value.FieldByIndex,
+// therefore every path element must address a struct field.
+func GetPathIndexPath(ptr interface{}, path string) ([]int, error) {
+	// convert the path into a list of path elements
+	pathelements, err := parsePath(path)
+	if err != nil {
+		return nil, err
+	}
+	if len(pathelements) == 0 {
+		return nil, errPathToShort
+	}
+
+	objType := reflect.TypeOf(ptr)
+	if objType == nil {
+		return nil, errObjNotExists
+	}
+
+	index := make([]int, 0, len(pathelements))
+	for _, pathelement := range pathelements {
+		// read all pointers away
+		for objType.Kind() == reflect.Ptr {
+			objType = objType.Elem()
+		}
+		if objType.Kind() != reflect.Struct {
+			return nil, errNoStructField
+		}
+
+		// the index of promoted fields already contains the way through the embedded structs
+		field, ok := objType.FieldByName(pathelement)
+		if !ok {
+			return nil, errObjNotExists
+		}
+		index = append(index, field.Index...)
+		objType = field.Type
+	}
+
+	return index, nil
+}
+
+// GetPathTag returns the value of the tag with the name of the struct field addressed by the path
+// and whether the field has this tag
+func GetPathTag(ptr interface{}, path string, tagName string) (string, bool, error) {
+	field, _, err := getPathStructField(ptr, path)
+	if err != nil {
+		return "", false, err
+	}
+
+	value, ok := field.Tag.Lookup(tagName)
+	return value, ok, nil
+}
+
+// getPathStructField resolves the path, whose last element has to address a struct field,
+// and returns the description of the field together with its value
+func getPathStructField(ptr interface{}, path string) (reflect.StructField, reflect.Value, error) {
+	// convert the path into a list of path elements
+	pathelements, err := parsePath(path)
+	if err != nil {
+		return reflect.StructField{}, reflect.Value{}, err
+	}
+	if len(pathelements) == 0 {
+		return reflect.StructField{}, reflect.Value{}, errPathToShort
+	}
+
+	objValue, err := getPathValue(reflect.ValueOf(ptr), pathelements[:len(pathelements)-1])
+	if err != nil {
+		return reflect.StructField{}, reflect.Value{}, err
+	}
+
+	// read all pointers and interfaces away
+	for (objValue.Kind() == reflect.Ptr || objValue.Kind() == reflect.Interface) && !objValue.IsNil() {
+		objValue = objValue.Elem()
+	}
+	if objValue.Kind() != reflect.Struct {
+		return reflect.StructField{}, reflect.Value{}, errNoStructField
+	}
+
+	field, ok := objValue.Type().FieldByName(pathelements[len(pathelements)-1])
+	if !ok {
+		return reflect.StructField{}, reflect.Value{}, errObjNotExists
+	}
+
+	// promoted fields of nil embedded pointers do not exist
+	fieldValue, err := objValue.FieldByIndexErr(field.Index)
+	if err != nil {
+		return reflect.StructField{}, reflect.Value{}, errObjNotExists
+	}
+
+	return field, fieldValue, nil
+}
+
+// ResolvePrefix resolves the path as far as possible. It returns the value at the deepest resolvable prefix
+// together with the path elements of this prefix, and the error which stopped the resolution, if any.
+// So "address.city.foo" results in the city, the elements ["address", "city"] and errPathToLong.
+func ResolvePrefix(ptr interface{}, path string) (resolved interface{}, matchedElements []string, err error) {
+	// convert the path into a list of path elements
+	pathelements, err := parsePath(path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	objValue := reflect.ValueOf(ptr)
+	matchedElements = make([]string, 0, len(pathelements))
+	for _, pathelement := range pathelements {
+		elemValue, stepErr := getPathValue(objValue, []string{pathelement})
+		if stepErr != nil {
+			err = stepErr
+			break
+		}
+		objValue = elemValue
+		matchedElements = append(matchedElements, pathelement)
+	}
+
+	// values of unexported fields are read from their memory, so structs and maps can be returned too
+	resolved, convErr := getInterfaceOfValue(getUnexportedValue(objValue))
+	if err == nil {
+		err = convErr
+	}
+
+	return resolved, matchedElements, err
+}
+
+// StepInfo describes the resolution of a single path element
+type StepInfo struct {
+	// Element is the path element
+	Element string
+	// Container is the kind of the struct, slice, array or map the element was resolved against
+	Container reflect.Kind
+	// Kind is the kind of the resulting value, after pointers and interfaces are read away
+	Kind reflect.Kind
+}
+
+// DescribePath resolves the path element by element and reports for every step the kind of the container
+// and of the resulting value. If an element can not be resolved, the steps resolved before are returned with the error.
+func DescribePath(ptr interface{}, path string) ([]StepInfo, error) {
+	// convert the path into a list of path elements
+	pathelements, err := parsePath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	objValue := reflect.ValueOf(ptr)
+	steps := make([]StepInfo, 0, len(pathelements))
+	for _, pathelement := range pathelements {
+		// read all pointers and interfaces away to get the container
+		container := objValue
+		for (container.Kind() == reflect.Ptr || container.Kind() == reflect.Interface) && !container.IsNil() {
+			container = container.Elem()
+		}
+
+		elemValue, err := getPathValue(objValue, []string{pathelement})
+		if err != nil {
+			return steps, err
+		}
+
+		steps = append(steps, StepInfo{Element: pathelement, Container: container.Kind(), Kind: elemValue.Kind()})
+		objValue = elemValue
+	}
+
+	return steps, nil
+}
+
+// ResolveRef reads the string addressed by the path and resolves it as a path from root,
+// so a field like "managerRef" holding "staff.0" returns the element it refers to.
+// A string it refers to is a reference again, so chains of references are followed to their end.
+// If a chain reaches a path it has already passed, errRefCycle is returned.
+func ResolveRef(root interface{}, path string) (interface{}, error) {
+	ref, err := GetPathString(root, path)
+	if err != nil {
+		return nil, err
+	}
+
+	pathelements, err := parsePath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	// the paths passed are compared by their elements, so different notations of the same path are detected
+	visited := map[string]bool{strings.Join(pathelements, "\x00"): true}
+	for {
+		refelements, err := parsePath(ref)
+		if err != nil {
+			return nil, err
+		}
+		key := strings.Join(refelements, "\x00")
+		if visited[key] {
+			return nil, errRefCycle
+		}
+		visited[key] = true
+
+		objValue, err := getPathValue(reflect.ValueOf(root), refelements)
+		if err != nil {
+			return nil, err
+		}
+
+		// values of unexported fields are read from their memory, so whole structs can be referenced
+		objValue = getUnexportedValue(objValue)
+		if objValue.Kind() != reflect.String {
+			return getInterfaceOfValue(objValue)
+		}
+		ref = objValue.String()
+	}
+}
+
+// GetPathRecv receives a value from the channel addressed by the path without blocking.
+// ok reports whether a value was received.
+func GetPathRecv(ptr interface{}, path string) (value interface{}, ok bool, err error) {
+	objValue, err := getPathReflectValue(ptr, path)
+	if err != nil {
+		return nil, false, err
+	}
+	if !objValue.IsValid() {
+		return nil, false, errObjNotExists
+	}
+	if objValue.Kind() != reflect.Chan || objValue.Type().ChanDir()&reflect.RecvDir == 0 {
+		return nil, false, errNoChannel
+	}
+
+	// a channel of an unexported field has to be made usable first
+	objValue = getUnexportedValue(objValue)
+	if !objValue.CanInterface() {
+		return nil, false, errIsNotInterfaceable
+	}
+
+	elemValue, ok := objValue.TryRecv()
+	if !ok {
+		return nil, false, nil
+	}
+
+	return elemValue.Interface(), true, nil
+}
+
+// GetPathContainsKey reports whether the map addressed by the path contains the key.
+// The key is converted to the key type of the map like a path element.
+func GetPathContainsKey(ptr interface{}, path string, key string) (bool, error) {
+	objValue, err := getPathReflectValue(ptr, path)
+	if err != nil {
+		return false, err
+	}
+	if !objValue.IsValid() {
+		return false, errObjNotExists
+	}
+
+	// a nil pointer is treated like its zero value
+	if objValue.Kind() == reflect.Ptr {
+		objValue = reflect.Zero(objValue.Type().Elem())
+	}
+	if objValue.Kind() != reflect.Map {
+		return false, errNoMap
+	}
+
+	keyValue, err := parseMapKey(key, objValue.Type().Key())
+	if err == errObjNotExists {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+
+	return objValue.MapIndex(keyValue).IsValid(), nil
+}
+
+// GetPathStructColumns returns the field names and the values of the struct addressed by the path in the order
+// of their declaration, so a struct can be exported as a table row. Unexported fields are read from their memory,
+// channels and functions result in nil values.
+func GetPathStructColumns(ptr interface{}, path string) (names []string, values []interface{}, err error) {
+	objValue, err := getPathReflectValue(ptr, path)
+	if err != nil {
+		return nil, nil, err
+	}
+	if objValue.Kind() == reflect.Ptr {
+		return nil, nil, errObjNotExists
+	}
+	if objValue.Kind() != reflect.Struct {
+		return nil, nil, errNoStruct
+	}
+
+	names = make([]string, objValue.NumField())
+	values = make([]interface{}, objValue.NumField())
+	for i := 0; i < objValue.NumField(); i++ {
+		names[i] = objValue.Type().Field(i).Name
+
+		value, err := getInterfaceOfValue(getUnexportedValue(objValue.Field(i)))
+		if err != nil && err != errUnsupportedKind {
+			return nil, nil, err
+		}
+		values[i] = value
+	}
+
+	return names, values, nil
+}
+
+// GetPathMapNth returns the nth key and value of the map addressed by the path in the natural order of its keys.
+// Numbers are ordered by their value, strings lexically and false comes before true.
+func GetPathMapNth(ptr interface{}, path string, n int) (key interface{}, value interface{}, err error) {
+	objValue, err := getPathReflectValue(ptr, path)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !objValue.IsValid() {
+		return nil, nil, errObjNotExists
+	}
+
+	// a nil pointer is treated like its zero value
+	if objValue.Kind() == reflect.Ptr {
+		objValue = reflect.Zero(objValue.Type().Elem())
+	}
+	if objValue.Kind() != reflect.Map {
+		return nil, nil, errNoMap
+	}
+
+	keys := objValue.MapKeys()
+	if n < 0 || n >= len(keys) {
+		return nil, nil, errOutOfRange
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return lessMapKey(keys[i], keys[j])
+	})
+
+	key, err = getInterfaceOfValue(keys[n])
+	if err != nil {
+		return nil, nil, err
+	}
+	value, err = getInterfaceOfValue(getUnexportedValue(objValue.MapIndex(keys[n])))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return key, value, nil
+}
+
+// lessMapKey reports whether the map key a is ordered before b.
+// Keys of other kinds than numbers, strings and booleans are ordered by their formatted value.
+func lessMapKey(a, b reflect.Value) bool {
+	switch a.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return a.Int() < b.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return a.Uint() < b.Uint()
+	case reflect.Float32, reflect.Float64:
+		return a.Float() < b.Float()
+	case reflect.String:
+		return a.String() < b.String()
+	case reflect.Bool:
+		return !a.Bool() && b.Bool()
+	default:
+		return fmt.Sprint(a) < fmt.Sprint(b)
+	}
+}
+
+// GetPathIndexOf returns the index of the first element of the slice or array addressed by the path,
+// which is deeply equal to the value, or -1 if there is no such element
+func GetPathIndexOf(ptr interface{}, path string, value interface{}) (int, error) {
+	objValue, err := getPathReflectValue(ptr, path)
+	if err != nil {
+		return -1, err
+	}
+	if !objValue.IsValid() {
+		return -1, errObjNotExists
+	}
+
+	// a nil pointer is treated like its zero value
+	if objValue.Kind() == reflect.Ptr {
+		objValue = reflect.Zero(objValue.Type().Elem())
+	}
+	if objValue.Kind() != reflect.Slice && objValue.Kind() != reflect.Array {
+		return -1, errNoSlice
+	}
+
+	for i := 0; i < objValue.Len(); i++ {
+		// elements of unexported fields are read from their memory
+		elem, err := getInterfaceOfValue(getUnexportedValue(objValue.Index(i)))
+		if err != nil {
+			return -1, err
+		}
+		if reflect.DeepEqual(elem, value) {
+			return i, nil
+		}
+	}
+
+	return -1, nil
+}
+
+// CanSetPath reports whether the element addressed by the path can be written.
+// This is the case for all addressable elements, because unexported fields can be set through their memory.
+// Map values and elements of structs passed by value are not addressable.
+func CanSetPath(ptr interface{}, path string) (bool, error) {
+	objValue, err := getPathReflectValue(ptr, path)
+	if err != nil {
+		return false, err
+	}
+	if !objValue.IsValid() {
+		return false, errObjNotExists
+	}
+
+	return objValue.CanAddr(), nil
+}
+
+// GetPathElemType returns the element type of the slice, array or map addressed by the path.
+// The type is determined from the declaration, so it is also available for empty or nil containers.
+func GetPathElemType(ptr interface{}, path string) (reflect.Type, error) {
+	objType, err := getPathContainerType(ptr, path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch objType.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return objType.Elem(), nil
+	}
+
+	return nil, errNoContainer
+}
+
+// GetPathKeyType returns the key type of the map addressed by the path
+func GetPathKeyType(ptr interface{}, path string) (reflect.Type, error) {
+	objType, err := getPathContainerType(ptr, path)
+	if err != nil {
+		return nil, err
+	}
+
+	if objType.Kind() == reflect.Map {
+		return objType.Key(), nil
+	}
+
+	return nil, errNoMap
+}
+
+// GetPathValueType returns the dynamic type of the element addressed by the path.
+// For interface values like the values of a map[string]interface{} the type of the contained value is returned
+// instead of the declared interface type, a nil interface has no type and results in nil.
+// Pointers are read away like by GetPathInterface, so a *string results in string, even if it is nil.
+func GetPathValueType(ptr interface{}, path string) (reflect.Type, error) {
+	objValue, err := getPathReflectValue(ptr, path)
+	if err != nil {
+		return nil, err
+	}
+	if !objValue.IsValid() {
+		return nil, errObjNotExists
+	}
+
+	// read all interfaces away
+	for objValue.Kind() == reflect.Interface {
+		if objValue.IsNil() {
+			return nil, nil
+		}
+		objValue = objValue.Elem()
+	}
+
+	// a nil pointer is not read away by the path, so only its type is
+	objType := objValue.Type()
+	for objType.Kind() == reflect.Ptr {
+		objType = objType.Elem()
+	}
+
+	return objType, nil
+}
+
+// getPathContainerType returns the declared type of the element addressed by the path with all pointers read away
+func getPathContainerType(ptr interface{}, path string) (reflect.Type, error) {
+	objValue, err := getPathReflectValue(ptr, path)
+	if err != nil {
+		return nil, err
+	}
+	if !objValue.IsValid() {
+		return nil, errObjNotExists
+	}
+
+	objType := objValue.Type()
+	for objType.Kind() == reflect.Ptr {
+		objType = objType.Elem()
+	}
+
+	return objType, nil
+}
+
+// GetPathDBValue returns the value, which the element addressed by the path stores in a database.
+// If the element implements driver.Valuer, the result of its Value method is returned, otherwise the element itself.
+func GetPathDBValue(ptr interface{}, path string) (driver.Value, error) {
+	objValue, err := getPathReflectValue(ptr, path)
+	if err != nil {
+		return nil, err
+	}
+
+	// a nil pointer has no value to store
+	if isNilPtr(objValue) {
+		return nil, nil
+	}
+	if valuer, ok := getValuer(objValue); ok {
+		return valuer.Value()
+	}
+
+	return getInterfaceOfValue(objValue)
+}
+
+// getValuer returns the driver.Valuer implemented by the value or by a pointer to it
+func getValuer(objValue reflect.Value) (driver.Valuer, bool) {
+	objValue = getUnexportedValue(objValue)
+	if objValue.CanAddr() {
+		if valuer, ok := objValue.Addr().Interface().(driver.Valuer); ok {
+			return valuer, true
+		}
+	}
+	if objValue.CanInterface() {
+		valuer, ok := objValue.Interface().(driver.Valuer)
+		return valuer, ok
+	}
+
+	return nil, false
+}
+
+// GetPathString returns the object addressed by the path as string
+func GetPathString(ptr interface{}, path string) (string, error) {
+	obj, err := GetPathInterface(ptr, path)
+	if err != nil {
+		return "", err
+	}
+	if obj == nil {
+		return "", errObjNotExists
+	}
+	sobj, ok := obj.(string)
+	if ok {
+		return sobj, nil
+	}
+
+	return "", errors.New("object is not a string")
+}
+
+// GetPathSubstring returns the runes from start up to end (exclusive) of the string addressed by the path,
+// so multibyte characters are never split
+func GetPathSubstring(ptr interface{}, path string, start, end int) (string, error) {
+	sobj, err := GetPathString(ptr, path)
+	if err != nil {
+		return "", err
+	}
+
+	runes := []rune(sobj)
+	if start < 0 || end > len(runes) || start > end {
+		return "", errOutOfRange
+	}
+
+	return string(runes[start:end]), nil
+}
+
+// GetPathRuneAt returns the nth rune of the string addressed by the path, so multibyte characters count as one
+func GetPathRuneAt(ptr interface{}, path string, n int) (rune, error) {
+	sobj, err := GetPathString(ptr, path)
+	if err != nil {
+		return 0, err
+	}
+
+	runes := []rune(sobj)
+	if n < 0 || n >= len(runes) {
+		return 0, errOutOfRange
+	}
+
+	return runes[n], nil
+}
+
+// GetPathBool returns the object addressed by the path as bool
+func GetPathBool(ptr interface{}, path string) (bool, error) {
+	obj, err := GetPathInterface(ptr, path)
+	if err != nil {
+		return false, err
+	}
+	if obj == nil {
+		return false, errObjNotExists
+	}
+	bobj, ok := obj.(bool)
+	if ok {
+		return bobj, nil
+	}
+
+	return false, errors.New("object is not a bool")
+}
+
+// GetPathBoolCoerce returns the object addressed by the path as bool.
+// In contrast to GetPathBool strings like "yes", "no", "on", "off" or "true" are interpreted case-insensitively,
+// and numbers are true if they are not zero.
+func GetPathBoolCoerce(ptr interface{}, path string) (bool, error) {
+	obj, err := GetPathInterface(ptr, path)
+	if err != nil {
+		return false, err
+	}
+	if obj == nil {
+		return false, errObjNotExists
+	}
+
+	switch bobj := obj.(type) {
+	case bool:
+		return bobj, nil
+	case string:
+		switch strings.ToLower(strings.TrimSpace(bobj)) {
+		case "yes", "y", "on":
+			return true, nil
+		case "no", "n", "off":
+			return false, nil
+		}
+		value, err := strconv.ParseBool(strings.TrimSpace(bobj))
+		if err != nil {
+			return false, errSyntax
+		}
+		return value, nil
+	}
+
+	// numbers are true if they are not zero
+	objValue := reflect.ValueOf(obj)
+	switch objValue.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return objValue.Int() != 0, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return objValue.Uint() != 0, nil
+	case reflect.Float32, reflect.Float64:
+		return objValue.Float() != 0, nil
+	}
+
+	return false, errors.New("object is not a bool")
+}
+
+// GetPathInt returns the object addressed by the path as int
+func GetPathInt(ptr interface{}, path string) (int, error) {
+	obj, err := GetPathInterface(ptr, path)
+	if err != nil {
+		return 0, err
+	}
+	if obj == nil {
+		return 0, errObjNotExists
+	}
+	iobj, ok := obj.(int)
+	if ok {
+		return iobj, nil
+	}
+
+	return 0, errors.New("object is not a int")
+}
+
+// GetPathIntStringy returns the object addressed by the path as int.
+// In contrast to GetPathInt a string is accepted too and parsed as int.
+func GetPathIntStringy(ptr interface{}, path string) (int, error) {
+	obj, err := GetPathInterface(ptr, path)
+	if err != nil {
+		return 0, err
+	}
+	if obj == nil {
+		return 0, errObjNotExists
+	}
+	switch iobj := obj.(type) {
+	case int:
+		return iobj, nil
+	case string:
+		value, err := strconv.ParseInt(strings.TrimSpace(iobj), 10, strconv.IntSize)
+		if err != nil {
+			return 0, errSyntax
+		}
+		return int(value), nil
+	}
+
+	return 0, errors.New("object is not a int")
+}
+
+// GetPathInt16 returns the object addressed by the path as int16
+func GetPathInt16(ptr interface{}, path string) (int16, error) {
+	obj, err := GetPathInterface(ptr, path)
+	if err != nil {
+		return 0, err
+	}
+
+	if obj == nil {
+		return 0, errObjNotExists
+	}
+	iobj, ok := obj.(int16)
+	if ok {
+		return iobj, nil
+	}
+
+	return 0, errors.New("object is not a int16")
+}
+
+// GetPathInt32 returns the object addressed by the path as int32
+func GetPathInt32(ptr interface{}, path string) (int32, error) {
+	obj, err := GetPathInterface(ptr, path)
+	if err != nil {
+		return 0, err
+	}
+
+	if obj == nil {
+		return 0, errObjNotExists
+	}
+	iobj, ok := obj.(int32)
+	if ok {
+		return iobj, nil
+	}
+
+	return 0, errors.New("object is not a int32")
+}
+
+// GetPathInt64 returns the object addressed by the path as int64
+func GetPathInt64(ptr interface{}, path string) (int64, error) {
+	obj, err := GetPathInterface(ptr, path)
+	if err != nil {
+		return 0, err
+	}
+	if obj == nil {
+		return 0, errObjNotExists
+	}
+	iobj, ok := obj.(int64)
+	if ok {
+		return iobj, nil
+	}
+
+	return 0, errors.New("object is not a int64")
+}
+
+// GetPathUint returns the object addressed by the path as uint
+func GetPathUint(ptr interface{}, path string) (uint, error) {
+	obj, err := GetPathInterface(ptr, path)
+	if err != nil {
+		return 0, err
+	}
+	if obj == nil {
+		return 0, errObjNotExists
+	}
+	iobj, ok := obj.(uint)
+	if ok {
+		return iobj, nil
+	}
+
+	return 0, errors.New("object is not a uint")
+}
+
+// GetPathUint8 returns the object addressed by the path as uint8
+func GetPathUint8(ptr interface{}, path string) (uint8, error) {
+	obj, err := GetPathInterface(ptr, path)
+	if err != nil {
+		return 0, err
+	}
 
 	if obj == nil {
 		return 0, errObjNotExists
@@ -651,6 +1699,30 @@ func GetPathFloat64(ptr interface{}, path string) (float64, error) {
 	return 0, errors.New("object is not a float64")
 }
 
+// GetPathFloat64Stringy returns the object addressed by the path as float64.
+// In contrast to GetPathFloat64 a string is accepted too and parsed as float64.
+func GetPathFloat64Stringy(ptr interface{}, path string) (float64, error) {
+	obj, err := GetPathInterface(ptr, path)
+	if err != nil {
+		return 0, err
+	}
+	if obj == nil {
+		return 0, errObjNotExists
+	}
+	switch fobj := obj.(type) {
+	case float64:
+		return fobj, nil
+	case string:
+		value, err := strconv.ParseFloat(strings.TrimSpace(fobj), 64)
+		if err != nil {
+			return 0, errSyntax
+		}
+		return value, nil
+	}
+
+	return 0, errors.New("object is not a float64")
+}
+
 // GetPathComplex64 returns the object addressed by the path as complex64
 func GetPathComplex64(ptr interface{}, path string) (complex64, error) {
 	obj, err := GetPathInterface(ptr, path)
@@ -702,6 +1774,27 @@ func GetPathByteSlice(ptr interface{}, path string) ([]byte, error) {
 	return nil, errors.New("object is not a []byte")
 }
 
+// GetPathByteArray returns the [n]byte array addressed by the path as a copy of its bytes
+func GetPathByteArray(ptr interface{}, path string, n int) ([]byte, error) {
+	elemValue, err := getPathReflectValue(ptr, path)
+	if err != nil {
+		return nil, err
+	}
+	if elemValue.Kind() != reflect.Array || elemValue.Type().Elem().Kind() != reflect.Uint8 {
+		return nil, errors.New("object is not a byte array")
+	}
+	if elemValue.Len() != n {
+		return nil, fmt.Errorf("%w: expected %d, but got %d", errArrayLength, n, elemValue.Len())
+	}
+
+	obj, err := getInterfaceOfValue(elemValue)
+	if err != nil {
+		return nil, err
+	}
+
+	return obj.([]byte), nil
+}
+
 // GetPathTime returns the object addressed by the path as time.Time
 func GetPathTime(ptr interface{}, path string) (time.Time, error) {
 	obj, err := GetPathInterface(ptr, path)
@@ -735,3 +1828,54 @@ func GetPathDuration(ptr interface{}, path string) (time.Duration, error) {
 
 	return 0, errors.New("object is not a time.Duration")
 }
+
+// GetPathFileMode returns the object addressed by the path as os.FileMode
+func GetPathFileMode(ptr interface{}, path string) (os.FileMode, error) {
+	obj, err := GetPathInterfaceTyped(ptr, path)
+	if err != nil {
+		return 0, err
+	}
+	if obj == nil {
+		return 0, errObjNotExists
+	}
+	fmobj, ok := obj.(os.FileMode)
+	if ok {
+		return fmobj, nil
+	}
+
+	return 0, errors.New("object is not an os.FileMode")
+}
+
+// GetPathMonth returns the object addressed by the path as time.Month
+func GetPathMonth(ptr interface{}, path string) (time.Month, error) {
+	obj, err := GetPathInterface(ptr, path)
+	if err != nil {
+		return 0, err
+	}
+	if obj == nil {
+		return 0, errObjNotExists
+	}
+	mobj, ok := obj.(time.Month)
+	if ok {
+		return mobj, nil
+	}
+
+	return 0, errors.New("object is not a time.Month")
+}
+
+// GetPathWeekday returns the object addressed by the path as time.Weekday
+func GetPathWeekday(ptr interface{}, path string) (time.Weekday, error) {
+	obj, err := GetPathInterface(ptr, path)
+	if err != nil {
+		return 0, err
+	}
+	if obj == nil {
+		return 0, errObjNotExists
+	}
+	wobj, ok := obj.(time.Weekday)
+	if ok {
+		return wobj, nil
+	}
+
+	return 0, errors.New("object is not a time.Weekday")
+}
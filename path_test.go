@@ -1,10 +1,17 @@
 package piranhas
 
 import (
+	"database/sql"
+	"database/sql/driver"
 	"errors"
+	"fmt"
+	"os"
 	"reflect"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
+	"unsafe"
 )
 
 func TestParsePath(t *testing.T) {
@@ -985,6 +992,88 @@ func TestGetPathByteSlice(t *testing.T) {
 	}
 }
 
+type byteArrayRecord struct {
+	id      [16]byte
+	digest  *[4]byte
+	ids     [][16]byte
+	counter [2]uint16
+}
+
+func TestGetPathByteArray(t *testing.T) {
+	id := [16]byte{0x12, 0x3e, 0x45, 0x67, 0xe8, 0x9b, 0x12, 0xd3, 0xa4, 0x56, 0x42, 0x66, 0x14, 0x17, 0x40, 0x00}
+	data := &byteArrayRecord{
+		id:      id,
+		digest:  &[4]byte{1, 2, 3, 4},
+		ids:     [][16]byte{id},
+		counter: [2]uint16{1, 2},
+	}
+
+	tests := []struct {
+		name     string
+		path     string
+		n        int
+		expected []byte
+		err      error
+	}{
+		{
+			name:     "[16]byte field",
+			path:     "id",
+			n:        16,
+			expected: id[:],
+		},
+		{
+			name:     "pointer to a byte array",
+			path:     "digest",
+			n:        4,
+			expected: []byte{1, 2, 3, 4},
+		},
+		{
+			name:     "byte array in a slice",
+			path:     "ids.0",
+			n:        16,
+			expected: id[:],
+		},
+		{
+			name: "wrong length",
+			path: "id",
+			n:    20,
+			err:  fmt.Errorf("%w: expected 20, but got 16", errArrayLength),
+		},
+		{
+			name: "array of another type",
+			path: "counter",
+			n:    2,
+			err:  errors.New("object is not a byte array"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := GetPathByteArray(data, test.path, test.n)
+			if (err == nil) != (test.err == nil) || (err != nil && err.Error() != test.err.Error()) {
+				t.Errorf("Expected error: %v, but got: %v", test.err, err)
+			}
+			if !reflect.DeepEqual(result, test.expected) {
+				t.Errorf("Expected %v, but got %v", test.expected, result)
+			}
+		})
+	}
+
+	// GetPathInterface returns a copy of the bytes, which does not change the array
+	obj, err := GetPathInterface(data, "id")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	bytes, ok := obj.([]byte)
+	if !ok || !reflect.DeepEqual(bytes, id[:]) {
+		t.Fatalf("Expected %v, but got %v", id[:], obj)
+	}
+	bytes[0] = 0
+	if data.id != id {
+		t.Errorf("Expected the array to be unchanged, but got %v", data.id)
+	}
+}
+
 func TestGetPathTime(t *testing.T) {
 	data := buildPersonData()
 
@@ -1077,6 +1166,2115 @@ func TestGetPathDuration(t *testing.T) {
 	}
 }
 
+func TestGetPathElemType(t *testing.T) {
+	data := &person{}
+
+	tests := []struct {
+		name     string
+		path     string
+		expected reflect.Type
+		err      error
+	}{
+		{
+			name:     "Empty slice of structs",
+			path:     "adresses1",
+			expected: reflect.TypeOf(address{}),
+			err:      nil,
+		},
+		{
+			name:     "Nil map",
+			path:     "hobbys",
+			expected: reflect.TypeOf(0),
+			err:      nil,
+		},
+		{
+			name:     "Object is not a container",
+			path:     "age",
+			expected: nil,
+			err:      errNoContainer,
+		},
+		{
+			name:     "Object does not exist",
+			path:     "unknown",
+			expected: nil,
+			err:      errObjNotExists,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := GetPathElemType(data, test.path)
+			if err != test.err {
+				t.Errorf("Expected error: %v, but got: %v", test.err, err)
+			}
+			if result != test.expected {
+				t.Errorf("Expected %v, but got %v", test.expected, result)
+			}
+		})
+	}
+}
+
+func TestGetPathValueType(t *testing.T) {
+	street := "Tellerstraße"
+	data := &struct {
+		values map[string]interface{}
+	}{
+		values: map[string]interface{}{
+			"name":    "Karl",
+			"age":     58,
+			"ratio":   0.75,
+			"address": address{street: street},
+			"street":  &street,
+			"hobbys":  []string{"Crochet"},
+			"nested":  map[string]interface{}{"level": uint8(3)},
+			"empty":   nil,
+			"nothing": (*string)(nil),
+		},
+	}
+
+	tests := []struct {
+		name     string
+		path     string
+		expected reflect.Type
+		err      error
+	}{
+		{name: "String", path: "values.name", expected: reflect.TypeOf(""), err: nil},
+		{name: "Int", path: "values.age", expected: reflect.TypeOf(0), err: nil},
+		{name: "Float", path: "values.ratio", expected: reflect.TypeOf(0.0), err: nil},
+		{name: "Struct", path: "values.address", expected: reflect.TypeOf(address{}), err: nil},
+		{name: "Pointer is read away", path: "values.street", expected: reflect.TypeOf(""), err: nil},
+		{name: "Nil pointer is read away", path: "values.nothing", expected: reflect.TypeOf(""), err: nil},
+		{name: "Slice", path: "values.hobbys", expected: reflect.TypeOf([]string{}), err: nil},
+		{name: "Nested map", path: "values.nested.level", expected: reflect.TypeOf(uint8(0)), err: nil},
+		{name: "Map itself", path: "values", expected: reflect.TypeOf(map[string]interface{}{}), err: nil},
+		{name: "Nil interface", path: "values.empty", expected: nil, err: nil},
+		{name: "Object does not exist", path: "values.unknown", expected: nil, err: errObjNotExists},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := GetPathValueType(data, test.path)
+			if err != test.err {
+				t.Errorf("Expected error: %v, but got: %v", test.err, err)
+			}
+			if result != test.expected {
+				t.Errorf("Expected %v, but got %v", test.expected, result)
+			}
+		})
+	}
+}
+
+func TestGetPathKeyType(t *testing.T) {
+	data := &person{}
+
+	tests := []struct {
+		name     string
+		path     string
+		expected reflect.Type
+		err      error
+	}{
+		{
+			name:     "Nil map",
+			path:     "hobbys",
+			expected: reflect.TypeOf(""),
+			err:      nil,
+		},
+		{
+			name:     "Object is not a map",
+			path:     "adresses1",
+			expected: nil,
+			err:      errNoMap,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := GetPathKeyType(data, test.path)
+			if err != test.err {
+				t.Errorf("Expected error: %v, but got: %v", test.err, err)
+			}
+			if result != test.expected {
+				t.Errorf("Expected %v, but got %v", test.expected, result)
+			}
+		})
+	}
+}
+
+type vehicle interface {
+	wheels() int
+}
+
+type car struct {
+	brand string
+	doors int
+}
+
+func (c car) wheels() int { return 4 }
+
+type bike struct {
+	brand string
+}
+
+func (b *bike) wheels() int { return 2 }
+
+type garage struct {
+	vehicle
+	city string
+}
+
+func TestGetPathInterfaceEmbeddedInterface(t *testing.T) {
+	tests := []struct {
+		name     string
+		ptr      interface{}
+		path     string
+		expected interface{}
+		err      error
+	}{
+		{
+			name:     "Promoted field of concrete struct",
+			ptr:      &garage{vehicle: car{brand: "Trabant", doors: 2}, city: "Zwickau"},
+			path:     "brand",
+			expected: "Trabant",
+			err:      nil,
+		},
+		{
+			name:     "Promoted field of concrete pointer",
+			ptr:      &garage{vehicle: &bike{brand: "Diamant"}},
+			path:     "brand",
+			expected: "Diamant",
+			err:      nil,
+		},
+		{
+			name:     "Field not available in concrete type",
+			ptr:      &garage{vehicle: &bike{brand: "Diamant"}},
+			path:     "doors",
+			expected: nil,
+			err:      errObjNotExists,
+		},
+		{
+			name:     "Nil interface",
+			ptr:      &garage{},
+			path:     "brand",
+			expected: nil,
+			err:      errObjNotExists,
+		},
+		{
+			name:     "Direct field",
+			ptr:      &garage{vehicle: car{brand: "Trabant"}, city: "Zwickau"},
+			path:     "city",
+			expected: "Zwickau",
+			err:      nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := GetPathInterface(test.ptr, test.path)
+			if err != test.err {
+				t.Errorf("Expected error: %v, but got: %v", test.err, err)
+			}
+			if !reflect.DeepEqual(result, test.expected) {
+				t.Errorf("Expected %v, but got %v", test.expected, result)
+			}
+		})
+	}
+}
+
+func TestGetPathCoalesce(t *testing.T) {
+	data := buildPersonData()
+
+	tests := []struct {
+		name     string
+		paths    []string
+		expected interface{}
+		err      error
+	}{
+		{
+			name:     "Third path resolves",
+			paths:    []string{"nickName", "address.country", "address.city"},
+			expected: "Berlin",
+			err:      nil,
+		},
+		{
+			name:     "First path resolves",
+			paths:    []string{"firstName", "address.city"},
+			expected: "Karl",
+			err:      nil,
+		},
+		{
+			name:     "No path resolves",
+			paths:    []string{"nickName", "address.country"},
+			expected: nil,
+			err:      errObjNotExists,
+		},
+		{
+			name:     "Malformed path",
+			paths:    []string{"address[city", "address.city"},
+			expected: nil,
+			err:      errEndSquareBracketsOpen,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := GetPathCoalesce(data, test.paths...)
+			if err != test.err {
+				t.Errorf("Expected error: %v, but got: %v", test.err, err)
+			}
+			if !reflect.DeepEqual(result, test.expected) {
+				t.Errorf("Expected %v, but got %v", test.expected, result)
+			}
+		})
+	}
+}
+
+func TestGetPathInterfaceUnsupportedKind(t *testing.T) {
+	type worker struct {
+		jobs     chan int
+		callback func() error
+		raw      unsafe.Pointer
+		name     string
+	}
+
+	number := 42
+	data := &worker{
+		jobs:     make(chan int, 1),
+		callback: func() error { return nil },
+		raw:      unsafe.Pointer(&number),
+		name:     "Karl",
+	}
+
+	tests := []struct {
+		name     string
+		path     string
+		expected interface{}
+		err      error
+	}{
+		{
+			name:     "Channel",
+			path:     "jobs",
+			expected: nil,
+			err:      errUnsupportedKind,
+		},
+		{
+			name:     "Function",
+			path:     "callback",
+			expected: nil,
+			err:      errUnsupportedKind,
+		},
+		{
+			name:     "Unsafe pointer",
+			path:     "raw",
+			expected: nil,
+			err:      errUnsupportedKind,
+		},
+		{
+			name:     "String",
+			path:     "name",
+			expected: "Karl",
+			err:      nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := GetPathInterface(data, test.path)
+			if err != test.err {
+				t.Errorf("Expected error: %v, but got: %v", test.err, err)
+			}
+			if !reflect.DeepEqual(result, test.expected) {
+				t.Errorf("Expected %v, but got %v", test.expected, result)
+			}
+		})
+	}
+}
+
+func TestGetPathIndexPath(t *testing.T) {
+	data := buildPersonData()
+
+	tests := []struct {
+		name     string
+		path     string
+		expected []int
+		value    interface{}
+		err      error
+	}{
+		{
+			name:     "Promoted embedded field",
+			path:     "number",
+			expected: []int{0, 0},
+			value:    "KI123",
+			err:      nil,
+		},
+		{
+			name:     "Nested field",
+			path:     "address.city",
+			expected: []int{5, 2},
+			value:    "Berlin",
+			err:      nil,
+		},
+		{
+			name:     "Slice on the way",
+			path:     "adresses1.0.city",
+			expected: nil,
+			err:      errNoStructField,
+		},
+		{
+			name:     "Object does not exist",
+			path:     "address.country",
+			expected: nil,
+			err:      errObjNotExists,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := GetPathIndexPath(data, test.path)
+			if err != test.err {
+				t.Errorf("Expected error: %v, but got: %v", test.err, err)
+			}
+			if !reflect.DeepEqual(result, test.expected) {
+				t.Errorf("Expected %v, but got %v", test.expected, result)
+			}
+
+			// the index path addresses the same value as the path
+			if err == nil {
+				value := reflect.ValueOf(data).Elem().FieldByIndex(result).String()
+				if value != test.value {
+					t.Errorf("Expected %v, but got %v", test.value, value)
+				}
+			}
+		})
+	}
+}
+
+func TestCanSetPath(t *testing.T) {
+	data := buildPersonData()
+
+	tests := []struct {
+		name     string
+		ptr      interface{}
+		path     string
+		expected bool
+		err      error
+	}{
+		{name: "Unexported struct field", ptr: data, path: "firstName", expected: true, err: nil},
+		{name: "Nested struct field", ptr: data, path: "address.city", expected: true, err: nil},
+		{name: "Exported struct field", ptr: data, path: "address.ZIP", expected: true, err: nil},
+		{name: "Slice element", ptr: data, path: "adresses1.1", expected: true, err: nil},
+		{name: "Field of slice element", ptr: data, path: "adresses1.0.street", expected: true, err: nil},
+		{name: "Map value", ptr: data, path: "hobbys.Motorcycle", expected: false, err: nil},
+		{name: "Struct passed by value", ptr: *data, path: "firstName", expected: false, err: nil},
+		{name: "Object does not exist", ptr: data, path: "address.country", expected: false, err: errObjNotExists},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := CanSetPath(test.ptr, test.path)
+			if err != test.err {
+				t.Errorf("Expected error: %v, but got: %v", test.err, err)
+			}
+			if result != test.expected {
+				t.Errorf("Expected %v, but got %v", test.expected, result)
+			}
+		})
+	}
+}
+
+// pages is a paginated collection, which computes its elements
+type pages struct {
+	count int
+	size  int
+}
+
+func (p *pages) Len() int {
+	return p.count
+}
+
+func (p *pages) Index(i int) interface{} {
+	return address{street: "Page", number: i * p.size}
+}
+
+// squares is an Indexable with value receivers
+type squares int
+
+func (s squares) Len() int {
+	return int(s)
+}
+
+func (s squares) Index(i int) interface{} {
+	return i * i
+}
+
+func TestGetPathInterfaceIndexable(t *testing.T) {
+	data := &struct {
+		results pages
+		numbers squares
+		any     interface{}
+	}{
+		results: pages{count: 3, size: 10},
+		numbers: squares(5),
+		any:     &pages{count: 1, size: 7},
+	}
+
+	tests := []struct {
+		name     string
+		path     string
+		expected interface{}
+		err      error
+	}{
+		{name: "Field of computed element", path: "results.2.number", expected: 20, err: nil},
+		{name: "Pointer receiver on unexported field", path: "results.1.street", expected: "Page", err: nil},
+		{name: "Value receiver", path: "numbers.4", expected: 16, err: nil},
+		{name: "Indexable in interface", path: "any.0.number", expected: 0, err: nil},
+		{name: "Fields are still reachable", path: "results.size", expected: 10, err: nil},
+		{name: "Index out of range", path: "results.3", expected: nil, err: errObjNotExists},
+		{name: "Negative index", path: "numbers.-1", expected: nil, err: errObjNotExists},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := GetPathInterface(data, test.path)
+			if err != test.err {
+				t.Errorf("Expected error: %v, but got: %v", test.err, err)
+			}
+			if !reflect.DeepEqual(result, test.expected) {
+				t.Errorf("Expected %v, but got %v", test.expected, result)
+			}
+		})
+	}
+}
+
+func TestGetPathInterfaceUnsupportedMapKey(t *testing.T) {
+	home := &address{street: "Tellerstraße"}
+	data := &struct {
+		visits  map[*address]int
+		byPlace map[address]int
+	}{
+		visits:  map[*address]int{home: 3},
+		byPlace: map[address]int{*home: 3},
+	}
+
+	tests := []struct {
+		name    string
+		path    string
+		message string
+	}{
+		{name: "Pointer key", path: "visits.0", message: "unsupported key type: *piranhas.address"},
+		{name: "Struct key", path: "byPlace.Tellerstraße", message: "unsupported key type: piranhas.address"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := GetPathInterface(data, test.path)
+			if !errors.Is(err, errUnsupportedMapKey) {
+				t.Errorf("Expected error: %v, but got: %v", errUnsupportedMapKey, err)
+			}
+			if err != nil && err.Error() != test.message {
+				t.Errorf("Expected message: %s, but got: %s", test.message, err)
+			}
+		})
+	}
+}
+
+func TestDescribePath(t *testing.T) {
+	data := buildPersonData()
+
+	tests := []struct {
+		name     string
+		path     string
+		expected []StepInfo
+		err      error
+	}{
+		{
+			name: "Struct, slice and struct",
+			path: "adresses1.0.city",
+			expected: []StepInfo{
+				{Element: "adresses1", Container: reflect.Struct, Kind: reflect.Slice},
+				{Element: "0", Container: reflect.Slice, Kind: reflect.Struct},
+				{Element: "city", Container: reflect.Struct, Kind: reflect.String},
+			},
+			err: nil,
+		},
+		{
+			name: "Struct and map",
+			path: "hobbys.Skydiving",
+			expected: []StepInfo{
+				{Element: "hobbys", Container: reflect.Struct, Kind: reflect.Map},
+				{Element: "Skydiving", Container: reflect.Map, Kind: reflect.Int},
+			},
+			err: nil,
+		},
+		{
+			name: "Pointers are read away",
+			path: "lastName",
+			expected: []StepInfo{
+				{Element: "lastName", Container: reflect.Struct, Kind: reflect.String},
+			},
+			err: nil,
+		},
+		{
+			name: "Steps before the error",
+			path: "address.city.foo",
+			expected: []StepInfo{
+				{Element: "address", Container: reflect.Struct, Kind: reflect.Struct},
+				{Element: "city", Container: reflect.Struct, Kind: reflect.String},
+			},
+			err: errPathToLong,
+		},
+		{
+			name:     "Empty path",
+			path:     "",
+			expected: []StepInfo{},
+			err:      nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := DescribePath(data, test.path)
+			if err != test.err {
+				t.Errorf("Expected error: %v, but got: %v", test.err, err)
+			}
+			if !reflect.DeepEqual(result, test.expected) {
+				t.Errorf("Expected %v, but got %v", test.expected, result)
+			}
+		})
+	}
+}
+
+func TestResolvePrefix(t *testing.T) {
+	data := buildPersonData()
+
+	tests := []struct {
+		name     string
+		path     string
+		resolved interface{}
+		matched  []string
+		err      error
+	}{
+		{
+			name:     "Path too long behind a scalar",
+			path:     "address.city.foo",
+			resolved: "Berlin",
+			matched:  []string{"address", "city"},
+			err:      errPathToLong,
+		},
+		{
+			name:     "Unknown field",
+			path:     "adresses1.1.country",
+			resolved: address{street: "Kanzlerpaltz", number: 1, city: "Berlin", ZIP: "10000"},
+			matched:  []string{"adresses1", "1"},
+			err:      errObjNotExists,
+		},
+		{
+			name:     "Unknown map key",
+			path:     "hobbys.Chess",
+			resolved: map[string]int{"Motorcycle": 10, "Skydiving": 9, "Crochet": 0},
+			matched:  []string{"hobbys"},
+			err:      errObjNotExists,
+		},
+		{
+			name:     "Complete path",
+			path:     "address.number",
+			resolved: 29,
+			matched:  []string{"address", "number"},
+			err:      nil,
+		},
+		{
+			name:     "First element fails",
+			path:     "unknown.city",
+			resolved: *data,
+			matched:  []string{},
+			err:      errObjNotExists,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			resolved, matched, err := ResolvePrefix(data, test.path)
+			if err != test.err {
+				t.Errorf("Expected error: %v, but got: %v", test.err, err)
+			}
+			if !reflect.DeepEqual(matched, test.matched) {
+				t.Errorf("Expected matched elements %v, but got %v", test.matched, matched)
+			}
+			if !reflect.DeepEqual(resolved, test.resolved) {
+				t.Errorf("Expected %v, but got %v", test.resolved, resolved)
+			}
+		})
+	}
+}
+
+type jsonMeta struct {
+	Version int `json:"version"`
+}
+
+type jsonLine struct {
+	ProductName string `json:"product_name"`
+	Quantity    int    `json:"qty,omitempty"`
+	Note        string
+	Internal    string `json:"-"`
+	secret      string
+}
+
+type jsonOrder struct {
+	jsonMeta
+	OrderID  string     `json:"order_id"`
+	Lines    []jsonLine `json:"line_items"`
+	Customer *struct {
+		FullName string `json:"full_name"`
+	} `json:"customer"`
+	Attributes map[string]string `json:"attrs"`
+	hidden     string
+}
+
+func TestGetJSON(t *testing.T) {
+	data := &jsonOrder{
+		jsonMeta: jsonMeta{Version: 2},
+		OrderID:  "A-17",
+		Lines: []jsonLine{
+			{ProductName: "Crochet hook", Quantity: 2, Note: "blue", Internal: "x", secret: "s"},
+			{ProductName: "Yarn", Quantity: 5},
+		},
+		Customer: &struct {
+			FullName string `json:"full_name"`
+		}{FullName: "Karl Ranseier"},
+		Attributes: map[string]string{"gift": "yes"},
+		hidden:     "h",
+	}
+
+	tests := []struct {
+		name     string
+		path     string
+		expected interface{}
+		err      error
+	}{
+		{name: "Tag name", path: "order_id", expected: "A-17", err: nil},
+		{name: "Tag name with options", path: "line_items.0.qty", expected: 2, err: nil},
+		{name: "Array index", path: "$.line_items[1].product_name", expected: "Yarn", err: nil},
+		{name: "Field without tag", path: "line_items.0.note", expected: "blue", err: nil},
+		{name: "Pointer to struct", path: "customer.full_name", expected: "Karl Ranseier", err: nil},
+		{name: "Promoted field of embedded struct", path: "version", expected: 2, err: nil},
+		{name: "Map key", path: "attrs.gift", expected: "yes", err: nil},
+		{name: "Go field name is not a json name", path: "OrderID", expected: nil, err: errObjNotExists},
+		{name: "Ignored field", path: "line_items.0.Internal", expected: nil, err: errObjNotExists},
+		{name: "Unexported field", path: "line_items.0.secret", expected: nil, err: errObjNotExists},
+		{name: "Unexported field of the root", path: "hidden", expected: nil, err: errObjNotExists},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := GetJSON(data, test.path)
+			if err != test.err {
+				t.Errorf("Expected error: %v, but got: %v", test.err, err)
+			}
+			if !reflect.DeepEqual(result, test.expected) {
+				t.Errorf("Expected %v, but got %v", test.expected, result)
+			}
+		})
+	}
+}
+
+type auditInfo struct {
+	id      string
+	created string
+}
+
+type document struct {
+	auditInfo
+	id    string
+	title string
+}
+
+func TestGetPathInterfaceStrict(t *testing.T) {
+	data := &document{
+		auditInfo: auditInfo{id: "audit-1", created: "2023-02-01"},
+		id:        "doc-1",
+		title:     "Report",
+	}
+
+	tests := []struct {
+		name     string
+		path     string
+		strict   bool
+		expected interface{}
+		err      error
+	}{
+		{name: "Shallowest field wins", path: "id", strict: false, expected: "doc-1", err: nil},
+		{name: "Ambiguous field in strict mode", path: "id", strict: true, expected: nil, err: errAmbiguousField},
+		{name: "Embedded struct addressed explicitly", path: "auditInfo.id", strict: true, expected: "audit-1", err: nil},
+		{name: "Promoted field without collision", path: "created", strict: true, expected: "2023-02-01", err: nil},
+		{name: "Direct field without collision", path: "title", strict: true, expected: "Report", err: nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var result interface{}
+			var err error
+			if test.strict {
+				result, err = GetPathInterfaceStrict(data, test.path)
+			} else {
+				result, err = GetPathInterface(data, test.path)
+			}
+			if err != test.err {
+				t.Errorf("Expected error: %v, but got: %v", test.err, err)
+			}
+			if !reflect.DeepEqual(result, test.expected) {
+				t.Errorf("Expected %v, but got %v", test.expected, result)
+			}
+		})
+	}
+}
+
+type employee struct {
+	name       string
+	managerRef string
+	level      int
+}
+
+type organisation struct {
+	ceo   employee
+	staff []employee
+}
+
+func TestResolveRef(t *testing.T) {
+	data := &organisation{
+		ceo: employee{name: "Karl", managerRef: ""},
+		staff: []employee{
+			{name: "Anna", managerRef: "ceo", level: 2},
+			{name: "Ben", managerRef: "staff.0", level: 3},
+			{name: "Cleo", managerRef: "staff[2].managerRef", level: 3},
+			{name: "Dan", managerRef: "staff.9", level: 3},
+			{name: "Eve", managerRef: "staff.5.managerRef", level: 4},
+			{name: "Finn", managerRef: "staff[4].managerRef", level: 4},
+			{name: "Gus", managerRef: "staff.1.managerRef", level: 4},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		path     string
+		expected interface{}
+		err      error
+	}{
+		{name: "Reference to a struct field", path: "staff.0.managerRef", expected: employee{name: "Karl"}, err: nil},
+		{name: "Reference to a slice element", path: "staff.1.managerRef", expected: employee{name: "Anna", managerRef: "ceo", level: 2}, err: nil},
+		{name: "Reference to itself", path: "staff.2.managerRef", expected: nil, err: errRefCycle},
+		{name: "Reference to a missing element", path: "staff.3.managerRef", expected: nil, err: errObjNotExists},
+		{name: "Cycle over two references", path: "staff.4.managerRef", expected: nil, err: errRefCycle},
+		{name: "Chain of references", path: "staff.6.managerRef", expected: employee{name: "Anna", managerRef: "ceo", level: 2}, err: nil},
+		{name: "Reference is not a string", path: "staff.0.level", expected: nil, err: errors.New("object is not a string")},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := ResolveRef(data, test.path)
+			if (err == nil) != (test.err == nil) || (err != nil && err.Error() != test.err.Error()) {
+				t.Errorf("Expected error: %v, but got: %v", test.err, err)
+			}
+			if !reflect.DeepEqual(result, test.expected) {
+				t.Errorf("Expected %v, but got %v", test.expected, result)
+			}
+		})
+	}
+}
+
+func TestGetPathSubstring(t *testing.T) {
+	data := buildPersonData()
+
+	tests := []struct {
+		name     string
+		path     string
+		start    int
+		end      int
+		expected string
+		err      error
+	}{
+		{name: "Prefix", path: "address.street", start: 0, end: 6, expected: "Teller", err: nil},
+		{name: "Umlaut is one rune", path: "address.street", start: 6, end: 12, expected: "straße", err: nil},
+		{name: "Single multibyte rune", path: "address.street", start: 10, end: 11, expected: "ß", err: nil},
+		{name: "Empty range", path: "address.street", start: 3, end: 3, expected: "", err: nil},
+		{name: "End outside of the string", path: "address.street", start: 6, end: 13, expected: "", err: errOutOfRange},
+		{name: "Negative start", path: "address.street", start: -1, end: 2, expected: "", err: errOutOfRange},
+		{name: "Start behind end", path: "address.street", start: 4, end: 2, expected: "", err: errOutOfRange},
+		{name: "Object is not a string", path: "age", start: 0, end: 1, expected: "", err: errors.New("object is not a string")},
+		{name: "Object does not exist", path: "address.country", start: 0, end: 1, expected: "", err: errObjNotExists},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := GetPathSubstring(data, test.path, test.start, test.end)
+			if (err == nil) != (test.err == nil) || (err != nil && err.Error() != test.err.Error()) {
+				t.Errorf("Expected error: %v, but got: %v", test.err, err)
+			}
+			if result != test.expected {
+				t.Errorf("Expected %s, but got %s", test.expected, result)
+			}
+		})
+	}
+}
+
+func TestGetPathRuneAt(t *testing.T) {
+	data := buildPersonData()
+
+	tests := []struct {
+		name     string
+		path     string
+		n        int
+		expected rune
+		err      error
+	}{
+		{name: "First rune", path: "address.street", n: 0, expected: 'T', err: nil},
+		{name: "Multibyte rune", path: "address.street", n: 10, expected: 'ß', err: nil},
+		{name: "Rune behind multibyte rune", path: "address.street", n: 11, expected: 'e', err: nil},
+		{name: "Behind the last rune", path: "address.street", n: 12, expected: 0, err: errOutOfRange},
+		{name: "Negative position", path: "address.street", n: -1, expected: 0, err: errOutOfRange},
+		{name: "Object is not a string", path: "age", n: 0, expected: 0, err: errors.New("object is not a string")},
+		{name: "Object does not exist", path: "address.country", n: 0, expected: 0, err: errObjNotExists},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := GetPathRuneAt(data, test.path, test.n)
+			if (err == nil) != (test.err == nil) || (err != nil && err.Error() != test.err.Error()) {
+				t.Errorf("Expected error: %v, but got: %v", test.err, err)
+			}
+			if result != test.expected {
+				t.Errorf("Expected %c, but got %c", test.expected, result)
+			}
+		})
+	}
+}
+
+func TestGetPathStringy(t *testing.T) {
+	type config struct {
+		port    string
+		ratio   string
+		timeout string
+		retries int
+		factor  float64
+	}
+
+	data := &config{port: "42", ratio: "3.14", timeout: "soon", retries: 3, factor: 1.5}
+
+	intTests := []struct {
+		name     string
+		path     string
+		expected int
+		err      error
+	}{
+		{name: "String is parsed", path: "port", expected: 42, err: nil},
+		{name: "Int is returned", path: "retries", expected: 3, err: nil},
+		{name: "String is not a number", path: "timeout", expected: 0, err: errSyntax},
+		{name: "Float is not an int", path: "factor", expected: 0, err: errors.New("object is not a int")},
+	}
+
+	for _, test := range intTests {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := GetPathIntStringy(data, test.path)
+			if (err == nil) != (test.err == nil) || (err != nil && err.Error() != test.err.Error()) {
+				t.Errorf("Expected error: %v, but got: %v", test.err, err)
+			}
+			if result != test.expected {
+				t.Errorf("Expected %v, but got %v", test.expected, result)
+			}
+		})
+	}
+
+	floatTests := []struct {
+		name     string
+		path     string
+		expected float64
+		err      error
+	}{
+		{name: "String is parsed", path: "ratio", expected: 3.14, err: nil},
+		{name: "Float is returned", path: "factor", expected: 1.5, err: nil},
+		{name: "String is not a number", path: "timeout", expected: 0, err: errSyntax},
+		{name: "Int is not a float", path: "retries", expected: 0, err: errors.New("object is not a float64")},
+	}
+
+	for _, test := range floatTests {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := GetPathFloat64Stringy(data, test.path)
+			if (err == nil) != (test.err == nil) || (err != nil && err.Error() != test.err.Error()) {
+				t.Errorf("Expected error: %v, but got: %v", test.err, err)
+			}
+			if result != test.expected {
+				t.Errorf("Expected %v, but got %v", test.expected, result)
+			}
+		})
+	}
+}
+
+func TestGetPathRecv(t *testing.T) {
+	type feed struct {
+		prices  chan float64
+		empty   chan float64
+		updates <-chan string
+		name    string
+	}
+
+	data := &feed{
+		prices: make(chan float64, 1),
+		empty:  make(chan float64, 1),
+		name:   "Ticker",
+	}
+	data.prices <- 87.5
+
+	tests := []struct {
+		name     string
+		path     string
+		expected interface{}
+		ok       bool
+		err      error
+	}{
+		{
+			name:     "Buffered channel with value",
+			path:     "prices",
+			expected: 87.5,
+			ok:       true,
+			err:      nil,
+		},
+		{
+			name:     "Empty channel",
+			path:     "empty",
+			expected: nil,
+			ok:       false,
+			err:      nil,
+		},
+		{
+			name:     "Nil channel",
+			path:     "updates",
+			expected: nil,
+			ok:       false,
+			err:      nil,
+		},
+		{
+			name:     "Object is not a channel",
+			path:     "name",
+			expected: nil,
+			ok:       false,
+			err:      errNoChannel,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, ok, err := GetPathRecv(data, test.path)
+			if err != test.err {
+				t.Errorf("Expected error: %v, but got: %v", test.err, err)
+			}
+			if ok != test.ok {
+				t.Errorf("Expected ok %v, but got %v", test.ok, ok)
+			}
+			if !reflect.DeepEqual(result, test.expected) {
+				t.Errorf("Expected %v, but got %v", test.expected, result)
+			}
+		})
+	}
+}
+
+func TestGetPathInterfaceArray(t *testing.T) {
+	type matrix struct {
+		row    [3]int
+		cells  [2][2]string
+		Public [3]int
+	}
+
+	data := &matrix{
+		row:    [3]int{1, 2, 3},
+		cells:  [2][2]string{{"a", "b"}, {"c", "d"}},
+		Public: [3]int{4, 5, 6},
+	}
+
+	tests := []struct {
+		path     string
+		expected interface{}
+	}{
+		{"row", [3]int{1, 2, 3}},
+		{"cells", [2][2]string{{"a", "b"}, {"c", "d"}}},
+		{"cells.1", [2]string{"c", "d"}},
+		{"Public", [3]int{4, 5, 6}},
+		{"row.2", 3},
+	}
+
+	for _, test := range tests {
+		result, err := GetPathInterface(data, test.path)
+		if err != nil {
+			t.Errorf("Error for path %s: %v", test.path, err)
+			continue
+		}
+
+		if !reflect.DeepEqual(result, test.expected) {
+			t.Errorf("For path %s, expected: %v, got: %v", test.path, test.expected, result)
+		}
+	}
+}
+
+func TestGetPathContainsKey(t *testing.T) {
+	data := buildPersonData()
+
+	tests := []struct {
+		name     string
+		path     string
+		key      string
+		expected bool
+		err      error
+	}{
+		{name: "Key exists", path: "hobbys", key: "Skydiving", expected: true, err: nil},
+		{name: "Key exists with zero value", path: "hobbys", key: "Crochet", expected: true, err: nil},
+		{name: "Key does not exist", path: "hobbys", key: "Chess", expected: false, err: nil},
+		{name: "Object is not a map", path: "adresses1", key: "0", expected: false, err: errNoMap},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := GetPathContainsKey(data, test.path, test.key)
+			if err != test.err {
+				t.Errorf("Expected error: %v, but got: %v", test.err, err)
+			}
+			if result != test.expected {
+				t.Errorf("Expected %v, but got %v", test.expected, result)
+			}
+		})
+	}
+}
+
+func TestGetPathStructColumns(t *testing.T) {
+	data := buildPersonData()
+	withFunc := &struct {
+		inner struct {
+			name     string
+			callback func()
+		}
+	}{}
+	withFunc.inner.name = "job"
+	withFunc.inner.callback = func() {}
+
+	tests := []struct {
+		name           string
+		ptr            interface{}
+		path           string
+		expectedNames  []string
+		expectedValues []interface{}
+		err            error
+	}{
+		{
+			name:           "Struct field",
+			ptr:            data,
+			path:           "address",
+			expectedNames:  []string{"street", "number", "city", "ZIP"},
+			expectedValues: []interface{}{"Tellerstraße", 29, "Berlin", "10553"},
+			err:            nil,
+		},
+		{
+			name:           "Struct in a slice",
+			ptr:            data,
+			path:           "adresses1.1",
+			expectedNames:  []string{"street", "number", "city", "ZIP"},
+			expectedValues: []interface{}{"Kanzlerpaltz", 1, "Berlin", "10000"},
+			err:            nil,
+		},
+		{
+			name:           "Function field is nil",
+			ptr:            withFunc,
+			path:           "inner",
+			expectedNames:  []string{"name", "callback"},
+			expectedValues: []interface{}{"job", nil},
+			err:            nil,
+		},
+		{
+			name:           "Object is not a struct",
+			ptr:            data,
+			path:           "hobbys",
+			expectedNames:  nil,
+			expectedValues: nil,
+			err:            errNoStruct,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			names, values, err := GetPathStructColumns(test.ptr, test.path)
+			if err != test.err {
+				t.Errorf("Expected error: %v, but got: %v", test.err, err)
+			}
+			if !reflect.DeepEqual(names, test.expectedNames) {
+				t.Errorf("Expected names %v, but got %v", test.expectedNames, names)
+			}
+			if !reflect.DeepEqual(values, test.expectedValues) {
+				t.Errorf("Expected values %v, but got %v", test.expectedValues, values)
+			}
+		})
+	}
+}
+
+func TestGetPathMapNth(t *testing.T) {
+	data := buildPersonData()
+	numbered := &struct {
+		ports map[int]string
+	}{ports: map[int]string{443: "https", 22: "ssh", 8080: "proxy", 80: "http"}}
+
+	tests := []struct {
+		name          string
+		ptr           interface{}
+		path          string
+		n             int
+		expectedKey   interface{}
+		expectedValue interface{}
+		err           error
+	}{
+		{name: "First entry", ptr: data, path: "hobbys", n: 0, expectedKey: "Crochet", expectedValue: 0, err: nil},
+		{name: "Third entry", ptr: data, path: "hobbys", n: 2, expectedKey: "Skydiving", expectedValue: 9, err: nil},
+		{name: "Numbers in numeric order", ptr: numbered, path: "ports", n: 2, expectedKey: 443, expectedValue: "https", err: nil},
+		{name: "Behind the last entry", ptr: data, path: "hobbys", n: 3, expectedKey: nil, expectedValue: nil, err: errOutOfRange},
+		{name: "Negative position", ptr: data, path: "hobbys", n: -1, expectedKey: nil, expectedValue: nil, err: errOutOfRange},
+		{name: "Object is not a map", ptr: data, path: "adresses1", n: 0, expectedKey: nil, expectedValue: nil, err: errNoMap},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			key, value, err := GetPathMapNth(test.ptr, test.path, test.n)
+			if err != test.err {
+				t.Errorf("Expected error: %v, but got: %v", test.err, err)
+			}
+			if key != test.expectedKey || value != test.expectedValue {
+				t.Errorf("Expected %v: %v, but got %v: %v", test.expectedKey, test.expectedValue, key, value)
+			}
+		})
+	}
+}
+
+func TestGetPathIndexOf(t *testing.T) {
+	data := buildPersonData()
+
+	tests := []struct {
+		name     string
+		path     string
+		value    interface{}
+		expected int
+		err      error
+	}{
+		{
+			name:     "Struct element found",
+			path:     "adresses1",
+			value:    address{street: "Kanzlerpaltz", number: 1, city: "Berlin", ZIP: "10000"},
+			expected: 1,
+			err:      nil,
+		},
+		{
+			name:     "Struct element not found",
+			path:     "adresses1",
+			value:    address{street: "Kanzlerpaltz"},
+			expected: -1,
+			err:      nil,
+		},
+		{
+			name:     "Byte element found",
+			path:     "fingerprint",
+			value:    uint8(108),
+			expected: 2,
+			err:      nil,
+		},
+		{
+			name:     "Object is not a slice",
+			path:     "hobbys",
+			value:    10,
+			expected: -1,
+			err:      errNoSlice,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := GetPathIndexOf(data, test.path, test.value)
+			if err != test.err {
+				t.Errorf("Expected error: %v, but got: %v", test.err, err)
+			}
+			if result != test.expected {
+				t.Errorf("Expected %v, but got %v", test.expected, result)
+			}
+		})
+	}
+}
+
+func TestGetPathBoolCoerce(t *testing.T) {
+	type switches struct {
+		debug   string
+		verbose string
+		color   string
+		legacy  string
+		trace   string
+		enabled bool
+		level   int
+		ratio   float64
+		raw     []byte
+	}
+
+	data := &switches{
+		debug:   "yes",
+		verbose: "OFF",
+		color:   "on",
+		legacy:  "True",
+		trace:   "maybe",
+		enabled: true,
+		level:   0,
+		ratio:   0.5,
+	}
+
+	tests := []struct {
+		name     string
+		path     string
+		expected bool
+		err      error
+	}{
+		{name: "yes", path: "debug", expected: true, err: nil},
+		{name: "OFF", path: "verbose", expected: false, err: nil},
+		{name: "on", path: "color", expected: true, err: nil},
+		{name: "True", path: "legacy", expected: true, err: nil},
+		{name: "Unknown word", path: "trace", expected: false, err: errSyntax},
+		{name: "Bool", path: "enabled", expected: true, err: nil},
+		{name: "Zero int", path: "level", expected: false, err: nil},
+		{name: "Non zero float", path: "ratio", expected: true, err: nil},
+		{name: "Object is not a bool", path: "raw", expected: false, err: errors.New("object is not a bool")},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := GetPathBoolCoerce(data, test.path)
+			if (err == nil) != (test.err == nil) || (err != nil && err.Error() != test.err.Error()) {
+				t.Errorf("Expected error: %v, but got: %v", test.err, err)
+			}
+			if result != test.expected {
+				t.Errorf("Expected %v, but got %v", test.expected, result)
+			}
+		})
+	}
+}
+
+type Tags []string
+
+type article struct {
+	Tags
+	title string
+}
+
+func TestGetPathInterfaceEmbeddedSlice(t *testing.T) {
+	data := &article{Tags: Tags{"go", "reflection"}, title: "Piranhas"}
+
+	tests := []struct {
+		name     string
+		path     string
+		expected interface{}
+		err      error
+	}{
+		{name: "Promoted index", path: "1", expected: "reflection", err: nil},
+		{name: "Index through field name", path: "Tags.0", expected: "go", err: nil},
+		{name: "Index out of range", path: "2", expected: nil, err: errObjNotExists},
+		{name: "Direct field", path: "title", expected: "Piranhas", err: nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := GetPathInterface(data, test.path)
+			if err != test.err {
+				t.Errorf("Expected error: %v, but got: %v", test.err, err)
+			}
+			if !reflect.DeepEqual(result, test.expected) {
+				t.Errorf("Expected %v, but got %v", test.expected, result)
+			}
+		})
+	}
+}
+
+type Attrs map[string]string
+
+type Limits map[int]int
+
+type resource struct {
+	Attrs
+	*Limits
+	name string
+}
+
+func TestGetPathInterfaceEmbeddedMap(t *testing.T) {
+	limits := Limits{1: 100}
+	data := &resource{Attrs: Attrs{"color": "red", "name": "attribute"}, Limits: &limits, name: "Piranhas"}
+	noLimits := &resource{Attrs: nil}
+
+	tests := []struct {
+		name     string
+		ptr      interface{}
+		path     string
+		expected interface{}
+		err      error
+	}{
+		{name: "Promoted key", ptr: data, path: "color", expected: "red", err: nil},
+		{name: "Key through field name", ptr: data, path: "Attrs.color", expected: "red", err: nil},
+		{name: "Promoted key of pointer to map", ptr: data, path: "1", expected: 100, err: nil},
+		{name: "Direct field before key", ptr: data, path: "name", expected: "Piranhas", err: nil},
+		{name: "Unknown key", ptr: data, path: "size", expected: nil, err: errObjNotExists},
+		{name: "Nil maps", ptr: noLimits, path: "color", expected: nil, err: errObjNotExists},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := GetPathInterface(test.ptr, test.path)
+			if err != test.err {
+				t.Errorf("Expected error: %v, but got: %v", test.err, err)
+			}
+			if !reflect.DeepEqual(result, test.expected) {
+				t.Errorf("Expected %v, but got %v", test.expected, result)
+			}
+		})
+	}
+}
+
+type Celsius float64
+
+type Level int
+
+type Sensor struct {
+	Name string
+}
+
+type station struct {
+	temperature Celsius
+	level       *Level
+	history     map[string]Celsius
+	sensor      Sensor
+	duration    time.Duration
+	plain       float64
+}
+
+func TestGetPathInterfaceTyped(t *testing.T) {
+	level := Level(3)
+	data := &station{
+		temperature: 21.5,
+		level:       &level,
+		history:     map[string]Celsius{"monday": 18.5},
+		sensor:      Sensor{Name: "DHT22"},
+		duration:    time.Minute,
+		plain:       1.5,
+	}
+
+	tests := []struct {
+		name     string
+		path     string
+		expected interface{}
+		err      error
+	}{
+		{name: "Defined float type", path: "temperature", expected: Celsius(21.5), err: nil},
+		{name: "Pointer to defined int type", path: "level", expected: Level(3), err: nil},
+		{name: "Defined type in map", path: "history.monday", expected: Celsius(18.5), err: nil},
+		{name: "Struct of unexported field", path: "sensor", expected: Sensor{Name: "DHT22"}, err: nil},
+		{name: "Duration", path: "duration", expected: time.Minute, err: nil},
+		{name: "Plain type", path: "plain", expected: 1.5, err: nil},
+		{name: "Object does not exist", path: "pressure", expected: nil, err: errObjNotExists},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := GetPathInterfaceTyped(data, test.path)
+			if err != test.err {
+				t.Errorf("Expected error: %v, but got: %v", test.err, err)
+			}
+			if !reflect.DeepEqual(result, test.expected) {
+				t.Errorf("Expected %v (%T), but got %v (%T)", test.expected, test.expected, result, result)
+			}
+		})
+	}
+}
+
+func TestGetPathFileMode(t *testing.T) {
+	data := &struct {
+		mode      os.FileMode
+		dirMode   *os.FileMode
+		plainMode uint32
+	}{
+		mode:      0644,
+		dirMode:   new(os.FileMode),
+		plainMode: 0600,
+	}
+	*data.dirMode = os.ModeDir | 0755
+
+	tests := []struct {
+		name     string
+		ptr      interface{}
+		path     string
+		expected os.FileMode
+		err      error
+	}{
+		{
+			name:     "Object is an os.FileMode",
+			ptr:      data,
+			path:     "mode",
+			expected: 0644,
+			err:      nil,
+		},
+		{
+			name:     "Object is a pointer to an os.FileMode",
+			ptr:      data,
+			path:     "dirMode",
+			expected: os.ModeDir | 0755,
+			err:      nil,
+		},
+		{
+			name:     "Object is a plain uint32",
+			ptr:      data,
+			path:     "plainMode",
+			expected: 0,
+			err:      errors.New("object is not an os.FileMode"),
+		},
+		{
+			name:     "Object does not exist",
+			ptr:      data,
+			path:     "unknown",
+			expected: 0,
+			err:      errObjNotExists,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := GetPathFileMode(test.ptr, test.path)
+			if (err == nil) != (test.err == nil) || (err != nil && err.Error() != test.err.Error()) {
+				t.Errorf("Expected error: %v, but got: %v", test.err, err)
+			}
+			if result != test.expected {
+				t.Errorf("Expected %v, but got %v", test.expected, result)
+			}
+		})
+	}
+}
+
+type schedule struct {
+	month   time.Month
+	weekday time.Weekday
+	day     int
+	months  map[string]time.Month
+}
+
+func TestGetPathMonth(t *testing.T) {
+	data := &schedule{
+		month:   time.June,
+		weekday: time.Friday,
+		day:     9,
+		months:  map[string]time.Month{"start": time.March},
+	}
+
+	tests := []struct {
+		name     string
+		path     string
+		expected time.Month
+		err      error
+	}{
+		{name: "Object is a time.Month", path: "month", expected: time.June, err: nil},
+		{name: "Month in map", path: "months.start", expected: time.March, err: nil},
+		{name: "Object is a time.Weekday", path: "weekday", expected: 0, err: errors.New("object is not a time.Month")},
+		{name: "Object is an int", path: "day", expected: 0, err: errors.New("object is not a time.Month")},
+		{name: "Object does not exist", path: "year", expected: 0, err: errObjNotExists},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := GetPathMonth(data, test.path)
+			if (err == nil) != (test.err == nil) || (err != nil && err.Error() != test.err.Error()) {
+				t.Errorf("Expected error: %v, but got: %v", test.err, err)
+			}
+			if result != test.expected {
+				t.Errorf("Expected %v, but got %v", test.expected, result)
+			}
+		})
+	}
+}
+
+func TestGetPathWeekday(t *testing.T) {
+	data := &schedule{
+		month:   time.June,
+		weekday: time.Friday,
+		day:     9,
+	}
+
+	tests := []struct {
+		name     string
+		path     string
+		expected time.Weekday
+		err      error
+	}{
+		{name: "Object is a time.Weekday", path: "weekday", expected: time.Friday, err: nil},
+		{name: "Object is a time.Month", path: "month", expected: 0, err: errors.New("object is not a time.Weekday")},
+		{name: "Object does not exist", path: "year", expected: 0, err: errObjNotExists},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := GetPathWeekday(data, test.path)
+			if (err == nil) != (test.err == nil) || (err != nil && err.Error() != test.err.Error()) {
+				t.Errorf("Expected error: %v, but got: %v", test.err, err)
+			}
+			if result != test.expected {
+				t.Errorf("Expected %v, but got %v", test.expected, result)
+			}
+		})
+	}
+}
+
+type wrapper[T any] struct {
+	value T
+	items []T
+	index map[string]T
+}
+
+type measured[T any] struct {
+	amount T
+	at     time.Time
+}
+
+func TestGetPathInterfaceGeneric(t *testing.T) {
+	at := time.Date(2023, time.February, 1, 0, 0, 0, 0, time.UTC)
+	data := &struct {
+		counter  wrapper[int]
+		nested   wrapper[wrapper[string]]
+		readings *wrapper[measured[float64]]
+	}{
+		counter: wrapper[int]{value: 3, items: []int{1, 2}, index: map[string]int{"a": 5}},
+		nested:  wrapper[wrapper[string]]{value: wrapper[string]{value: "inner", items: []string{"x"}}},
+		readings: &wrapper[measured[float64]]{
+			items: []measured[float64]{{amount: 1.5, at: at}},
+			index: map[string]measured[float64]{"last": {amount: 2.5, at: at}},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		path     string
+		expected interface{}
+		err      error
+	}{
+		{name: "Field of generic struct", path: "counter.value", expected: 3, err: nil},
+		{name: "Slice of type parameter", path: "counter.items.1", expected: 2, err: nil},
+		{name: "Map of type parameter", path: "counter.index.a", expected: 5, err: nil},
+		{name: "Nested instantiation", path: "nested.value.items.0", expected: "x", err: nil},
+		{name: "Pointer to generic struct", path: "readings.items.0.amount", expected: 1.5, err: nil},
+		{name: "Generic struct in map", path: "readings.index.last.amount", expected: 2.5, err: nil},
+		{name: "Time in generic struct", path: "readings.index.last.at", expected: at, err: nil},
+		{name: "Unknown field", path: "counter.values", expected: nil, err: errObjNotExists},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := GetPathInterface(data, test.path)
+			if err != test.err {
+				t.Errorf("Expected error: %v, but got: %v", test.err, err)
+			}
+			if !reflect.DeepEqual(result, test.expected) {
+				t.Errorf("Expected %v, but got %v", test.expected, result)
+			}
+		})
+	}
+}
+
+// isEventPayload models a protobuf oneof, which is implemented by the wrapper structs
+type isEventPayload interface {
+	isEventPayload()
+}
+
+type orderPlaced struct {
+	orderId string
+	amount  float64
+}
+
+type orderCancelled struct {
+	orderId string
+	reason  string
+}
+
+// eventOrderPlaced and eventOrderCancelled are the generated wrappers of the oneof cases
+type eventOrderPlaced struct {
+	OrderPlaced *orderPlaced
+}
+
+type eventOrderCancelled struct {
+	OrderCancelled *orderCancelled
+}
+
+func (*orderPlaced) isEventPayload()         {}
+func (*eventOrderPlaced) isEventPayload()    {}
+func (*eventOrderCancelled) isEventPayload() {}
+
+type eventEnvelope struct {
+	event struct {
+		id      string
+		payload isEventPayload
+	}
+}
+
+func TestGetPathInterfaceOneof(t *testing.T) {
+	placed := &eventEnvelope{}
+	placed.event.payload = &orderPlaced{orderId: "A-17", amount: 9.5}
+
+	wrappedPlaced := &eventEnvelope{}
+	wrappedPlaced.event.payload = &eventOrderPlaced{OrderPlaced: &orderPlaced{orderId: "A-18", amount: 3}}
+
+	wrappedCancelled := &eventEnvelope{}
+	wrappedCancelled.event.payload = &eventOrderCancelled{OrderCancelled: &orderCancelled{orderId: "A-19", reason: "duplicate"}}
+
+	unset := &eventEnvelope{}
+
+	tests := []struct {
+		name     string
+		ptr      interface{}
+		path     string
+		expected interface{}
+		err      error
+	}{
+		{name: "Concrete type in interface", ptr: placed, path: "event.payload.orderId", expected: "A-17", err: nil},
+		{name: "Other field of concrete type", ptr: placed, path: "event.payload.amount", expected: 9.5, err: nil},
+		{name: "Wrapper of first case", ptr: wrappedPlaced, path: "event.payload.OrderPlaced.orderId", expected: "A-18", err: nil},
+		{name: "Wrapper of second case", ptr: wrappedCancelled, path: "event.payload.OrderCancelled.reason", expected: "duplicate", err: nil},
+		{name: "Case which is not set", ptr: wrappedCancelled, path: "event.payload.OrderPlaced.orderId", expected: nil, err: errObjNotExists},
+		{name: "Field of the other case", ptr: placed, path: "event.payload.reason", expected: nil, err: errObjNotExists},
+		{name: "Unset oneof", ptr: unset, path: "event.payload", expected: nil, err: nil},
+		{name: "Unset oneof with field", ptr: unset, path: "event.payload.orderId", expected: nil, err: errPathToLong},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := GetPathInterface(test.ptr, test.path)
+			if err != test.err {
+				t.Errorf("Expected error: %v, but got: %v", test.err, err)
+			}
+			if !reflect.DeepEqual(result, test.expected) {
+				t.Errorf("Expected %v, but got %v", test.expected, result)
+			}
+		})
+	}
+
+	// the set case is discriminated by the dynamic type
+	for ptr, expected := range map[*eventEnvelope]reflect.Type{
+		placed:           reflect.TypeOf(orderPlaced{}),
+		wrappedPlaced:    reflect.TypeOf(eventOrderPlaced{}),
+		wrappedCancelled: reflect.TypeOf(eventOrderCancelled{}),
+	} {
+		result, err := GetPathValueType(ptr, "event.payload")
+		if err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+		if result != expected {
+			t.Errorf("Expected %v, but got %v", expected, result)
+		}
+	}
+}
+
+type pointerArrays struct {
+	matrix *[3]int
+	empty  *[3]int
+	grid   *[2]*[2]int
+}
+
+func TestGetPathInterfacePointerToArray(t *testing.T) {
+	data := &pointerArrays{
+		matrix: &[3]int{4, 5, 6},
+		grid:   &[2]*[2]int{{1, 2}, nil},
+	}
+
+	tests := []struct {
+		name     string
+		path     string
+		expected interface{}
+		err      error
+	}{
+		{name: "First element", path: "matrix.0", expected: 4, err: nil},
+		{name: "Last element", path: "matrix.2", expected: 6, err: nil},
+		{name: "Index out of range", path: "matrix.3", expected: nil, err: errObjNotExists},
+		{name: "Whole array", path: "matrix", expected: [3]int{4, 5, 6}, err: nil},
+		{name: "Pointer to array in pointer to array", path: "grid.0.1", expected: 2, err: nil},
+		{name: "Nil pointer", path: "empty", expected: nil, err: nil},
+		{name: "Element of nil pointer", path: "empty.0", expected: nil, err: errPathToLong},
+		{name: "Element of nil pointer in array", path: "grid.1.0", expected: nil, err: errPathToLong},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := GetPathInterface(data, test.path)
+			if err != test.err {
+				t.Errorf("Expected error: %v, but got: %v", test.err, err)
+			}
+			if !reflect.DeepEqual(result, test.expected) {
+				t.Errorf("Expected %v, but got %v", test.expected, result)
+			}
+		})
+	}
+}
+
+type Timestamp time.Time
+
+type auditRecord struct {
+	created  Timestamp
+	modified *Timestamp
+	history  []Timestamp
+}
+
+func TestGetPathInterfaceTimeWrapper(t *testing.T) {
+	cetLocation := time.FixedZone("CET", 1*60*60)
+	created := time.Date(2023, time.March, 14, 15, 9, 26, 0, cetLocation)
+	modified := Timestamp(time.Date(2024, time.January, 2, 3, 4, 5, 0, time.UTC))
+	data := &auditRecord{
+		created:  Timestamp(created),
+		modified: &modified,
+		history:  []Timestamp{Timestamp(created)},
+	}
+
+	tests := []struct {
+		name     string
+		path     string
+		expected time.Time
+	}{
+		{name: "Wrapper type", path: "created", expected: created},
+		{name: "Pointer to wrapper type", path: "modified", expected: time.Time(modified)},
+		{name: "Wrapper type in slice", path: "history.0", expected: created},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := GetPathTime(data, test.path)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !result.Equal(test.expected) || result.Location().String() != test.expected.Location().String() {
+				t.Errorf("Expected %v, but got %v", test.expected, result)
+			}
+		})
+	}
+
+	// the time value is usable like any other time
+	result, err := GetPathTime(data, "created")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result.Format(time.RFC3339) != "2023-03-14T15:09:26+01:00" {
+		t.Errorf("Expected 2023-03-14T15:09:26+01:00, but got %s", result.Format(time.RFC3339))
+	}
+}
+
+type pointerInterfaces struct {
+	ptrStruct  *interface{}
+	structVal  *interface{}
+	nested     *interface{}
+	emptyIface *interface{}
+	nilPtr     *interface{}
+}
+
+func TestGetPathInterfacePointerToInterface(t *testing.T) {
+	var ptrStruct interface{} = &address{street: "Tellerstraße", city: "Berlin"}
+	var structVal interface{} = address{street: "Müllerstr", city: "Hamburg"}
+	var inner interface{} = map[string]interface{}{"ports": []int{80, 443}}
+	var nested interface{} = &inner
+	var emptyIface interface{}
+
+	data := &pointerInterfaces{
+		ptrStruct:  &ptrStruct,
+		structVal:  &structVal,
+		nested:     &nested,
+		emptyIface: &emptyIface,
+	}
+
+	tests := []struct {
+		name     string
+		path     string
+		expected interface{}
+		err      error
+	}{
+		{name: "Pointer to struct in interface", path: "ptrStruct.city", expected: "Berlin", err: nil},
+		{name: "Struct in interface", path: "structVal.street", expected: "Müllerstr", err: nil},
+		{name: "Pointer to interface in interface", path: "nested.ports.1", expected: 443, err: nil},
+		{name: "Nil interface", path: "emptyIface", expected: nil, err: nil},
+		{name: "Field of nil interface", path: "emptyIface.city", expected: nil, err: errPathToLong},
+		{name: "Nil pointer", path: "nilPtr", expected: nil, err: nil},
+		{name: "Field of nil pointer", path: "nilPtr.city", expected: nil, err: errPathToLong},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := GetPathInterface(data, test.path)
+			if err != test.err {
+				t.Errorf("Expected error: %v, but got: %v", test.err, err)
+			}
+			if !reflect.DeepEqual(result, test.expected) {
+				t.Errorf("Expected %v, but got %v", test.expected, result)
+			}
+		})
+	}
+}
+
+type atomicCounters struct {
+	requests atomic.Int64
+	healthy  atomic.Bool
+	config   atomic.Value
+	current  atomic.Pointer[address]
+	empty    atomic.Value
+	byName   map[string]*atomic.Uint32
+}
+
+func TestGetPathInterfaceAtomic(t *testing.T) {
+	data := &atomicCounters{byName: map[string]*atomic.Uint32{"errors": {}}}
+	data.requests.Store(42)
+	data.healthy.Store(true)
+	data.config.Store(map[string]int{"retries": 3})
+	data.current.Store(&address{street: "Tellerstraße", city: "Berlin"})
+	data.byName["errors"].Store(7)
+
+	tests := []struct {
+		name     string
+		path     string
+		expected interface{}
+		err      error
+	}{
+		{name: "atomic.Int64", path: "requests", expected: int64(42), err: nil},
+		{name: "atomic.Bool", path: "healthy", expected: true, err: nil},
+		{name: "atomic.Value", path: "config", expected: map[string]int{"retries": 3}, err: nil},
+		{name: "atomic.Pointer", path: "current", expected: address{street: "Tellerstraße", city: "Berlin"}, err: nil},
+		{name: "Empty atomic.Value", path: "empty", expected: nil, err: nil},
+		{name: "Pointer to atomic.Uint32 in a map", path: "byName.errors", expected: uint32(7), err: nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := GetPathInterface(data, test.path)
+			if err != test.err {
+				t.Errorf("Expected error: %v, but got: %v", test.err, err)
+			}
+			if !reflect.DeepEqual(result, test.expected) {
+				t.Errorf("Expected %v, but got %v", test.expected, result)
+			}
+		})
+	}
+
+	result, err := GetPathInt64(data, "requests")
+	if err != nil || result != 42 {
+		t.Errorf("Expected 42, but got %v with error %v", result, err)
+	}
+}
+
+type cents int64
+
+// Value stores the amount as a decimal string
+func (c cents) Value() (driver.Value, error) {
+	return fmt.Sprintf("%d.%02d", c/100, c%100), nil
+}
+
+type tags []string
+
+// Value stores the tags comma separated
+func (t *tags) Value() (driver.Value, error) {
+	if len(*t) == 0 {
+		return nil, errors.New("no tags")
+	}
+	return strings.Join(*t, ","), nil
+}
+
+type dbOrder struct {
+	id       int
+	amount   cents
+	tags     tags
+	noTags   tags
+	note     sql.NullString
+	shipping *cents
+}
+
+func TestGetPathDBValue(t *testing.T) {
+	data := &dbOrder{
+		id:     7,
+		amount: 1999,
+		tags:   tags{"new", "paid"},
+		note:   sql.NullString{String: "fragile", Valid: true},
+	}
+
+	tests := []struct {
+		name     string
+		path     string
+		expected driver.Value
+		err      error
+	}{
+		{name: "Valuer with value receiver", path: "amount", expected: "19.99", err: nil},
+		{name: "Valuer with pointer receiver", path: "tags", expected: "new,paid", err: nil},
+		{name: "Valuer returning an error", path: "noTags", expected: nil, err: errors.New("no tags")},
+		{name: "Valuer of database/sql", path: "note", expected: "fragile", err: nil},
+		{name: "Nil pointer to a Valuer", path: "shipping", expected: nil, err: nil},
+		{name: "No Valuer", path: "id", expected: 7, err: nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := GetPathDBValue(data, test.path)
+			if (err == nil) != (test.err == nil) || (err != nil && err.Error() != test.err.Error()) {
+				t.Errorf("Expected error: %v, but got: %v", test.err, err)
+			}
+			if !reflect.DeepEqual(result, test.expected) {
+				t.Errorf("Expected %v, but got %v", test.expected, result)
+			}
+		})
+	}
+}
+
+type option[T any] struct {
+	val T
+	ok  bool
+}
+
+// Get returns the value and whether it is present
+func (o option[T]) Get() (interface{}, bool) {
+	return o.val, o.ok
+}
+
+// some returns a present option holding the value
+func some[T any](val T) option[T] {
+	return option[T]{val: val, ok: true}
+}
+
+type optionalProfile struct {
+	nickname option[string]
+	email    option[string]
+	address  option[address]
+	manager  option[*optionalProfile]
+	scores   option[option[[]int]]
+}
+
+func TestGetPathInterfaceOptional(t *testing.T) {
+	data := &optionalProfile{
+		nickname: some("Kalle"),
+		address:  some(address{street: "Tellerstraße", city: "Berlin"}),
+		manager:  some(&optionalProfile{nickname: some("Boss")}),
+		scores:   some(some([]int{3, 5, 8})),
+	}
+
+	tests := []struct {
+		name     string
+		path     string
+		expected interface{}
+		err      error
+	}{
+		{name: "Present option", path: "nickname", expected: "Kalle", err: nil},
+		{name: "Absent option", path: "email", expected: nil, err: nil},
+		{name: "Field of present option", path: "address.city", expected: "Berlin", err: nil},
+		{name: "Field of absent option", path: "manager.email.length", expected: nil, err: errPathToLong},
+		{name: "Option of pointer", path: "manager.nickname", expected: "Boss", err: nil},
+		{name: "Nested options", path: "scores.2", expected: 8, err: nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := GetPathInterface(data, test.path)
+			if err != test.err {
+				t.Errorf("Expected error: %v, but got: %v", test.err, err)
+			}
+			if !reflect.DeepEqual(result, test.expected) {
+				t.Errorf("Expected %v, but got %v", test.expected, result)
+			}
+		})
+	}
+}
+
+type labeledPerson struct {
+	name    string `label:"Name"`
+	age     int    `default:"18" label:"Age in years"`
+	email   string `label:""`
+	address labeledAddress
+	friends []labeledPerson
+}
+
+type labeledAddress struct {
+	city string `default:"Berlin" label:"City"`
+}
+
+func TestGetPathTag(t *testing.T) {
+	data := &labeledPerson{friends: []labeledPerson{{}}}
+
+	tests := []struct {
+		name     string
+		path     string
+		tagName  string
+		expected string
+		present  bool
+		err      error
+	}{
+		{name: "Default tag", path: "age", tagName: "default", expected: "18", present: true, err: nil},
+		{name: "Custom tag", path: "age", tagName: "label", expected: "Age in years", present: true, err: nil},
+		{name: "Missing tag", path: "name", tagName: "default", expected: "", present: false, err: nil},
+		{name: "Empty tag", path: "email", tagName: "label", expected: "", present: true, err: nil},
+		{name: "Nested field", path: "address.city", tagName: "label", expected: "City", present: true, err: nil},
+		{name: "Field of slice element", path: "friends.0.age", tagName: "label", expected: "Age in years", present: true, err: nil},
+		{name: "Not a struct field", path: "friends.0", tagName: "label", expected: "", present: false, err: errNoStructField},
+		{name: "Unknown field", path: "phone", tagName: "label", expected: "", present: false, err: errObjNotExists},
+		{name: "Empty path", path: "", tagName: "label", expected: "", present: false, err: errPathToShort},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, present, err := GetPathTag(data, test.path, test.tagName)
+			if err != test.err {
+				t.Errorf("Expected error: %v, but got: %v", test.err, err)
+			}
+			if result != test.expected || present != test.present {
+				t.Errorf("Expected %q (%v), but got %q (%v)", test.expected, test.present, result, present)
+			}
+		})
+	}
+}
+
+type resultAddress struct {
+	city string
+}
+
+type resultPerson struct {
+	name     string
+	nickname *string
+	address  *resultAddress
+	friends  []resultPerson
+	hobbys   map[string]int
+}
+
+func TestGetPathResult(t *testing.T) {
+	data := &resultPerson{
+		name:    "Karl",
+		friends: []resultPerson{{name: "Heinz"}},
+		hobbys:  map[string]int{"Motorcycle": 10},
+	}
+
+	tests := []struct {
+		name     string
+		path     string
+		expected interface{}
+		found    bool
+		err      error
+	}{
+		{name: "Present field", path: "name", expected: "Karl", found: true, err: nil},
+		{name: "Present slice element", path: "friends.0.name", expected: "Heinz", found: true, err: nil},
+		{name: "Present map value", path: "hobbys.Motorcycle", expected: 10, found: true, err: nil},
+		{name: "Present nil pointer", path: "nickname", expected: nil, found: true, err: nil},
+		{name: "Present nil struct pointer", path: "address", expected: nil, found: true, err: nil},
+		{name: "Absent field", path: "phone", expected: nil, found: false, err: nil},
+		{name: "Absent index", path: "friends.3.name", expected: nil, found: false, err: nil},
+		{name: "Absent map key", path: "hobbys.Crochet", expected: nil, found: false, err: nil},
+		{name: "Through nil pointer", path: "address.city", expected: nil, found: false, err: errPathToLong},
+		{name: "Malformed path", path: "friends[0.name", expected: nil, found: false, err: errEndSquareBracketsOpen},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, found, err := GetPathResult(data, test.path)
+			if err != test.err {
+				t.Errorf("Expected error: %v, but got: %v", test.err, err)
+			}
+			if !reflect.DeepEqual(result, test.expected) || found != test.found {
+				t.Errorf("Expected %v (%v), but got %v (%v)", test.expected, test.found, result, found)
+			}
+		})
+	}
+}
+
 // helper function to compare slices
 func sliceEqual(a, b []string) bool {
 	if len(a) != len(b) {
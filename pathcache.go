@@ -0,0 +1,93 @@
+package piranhas
+
+import (
+	"container/list"
+	"sync"
+)
+
+// defaultPathCacheSize is the number of parsed paths kept, if SetPathCacheSize is not called
+const defaultPathCacheSize = 1024
+
+// pathCacheEntry is an element of the list of the path cache
+type pathCacheEntry struct {
+	path         string
+	pathelements []string
+}
+
+// pathCache keeps the most recently parsed paths, the least recently used path is evicted beyond its size
+type pathCache struct {
+	mutex   sync.Mutex
+	size    int
+	order   *list.List
+	entries map[string]*list.Element
+}
+
+var parsedPaths = newPathCache(defaultPathCacheSize)
+
+// newPathCache returns an empty path cache of the size
+func newPathCache(size int) *pathCache {
+	return &pathCache{
+		size:    size,
+		order:   list.New(),
+		entries: make(map[string]*list.Element),
+	}
+}
+
+// SetPathCacheSize sets the number of parsed paths, which are kept to be reused by the GetPath functions.
+// The least recently used paths are evicted beyond this number, a size of 0 or less disables the cache.
+func SetPathCacheSize(size int) {
+	parsedPaths.mutex.Lock()
+	defer parsedPaths.mutex.Unlock()
+
+	parsedPaths.size = size
+	parsedPaths.evict()
+}
+
+// get returns the path elements of a cached path and marks it as recently used
+func (c *pathCache) get(path string) ([]string, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	element, ok := c.entries[path]
+	if !ok {
+		return nil, false
+	}
+	c.order.MoveToFront(element)
+
+	return element.Value.(*pathCacheEntry).pathelements, true
+}
+
+// put adds the path elements of a path and evicts the least recently used paths beyond the size
+func (c *pathCache) put(path string, pathelements []string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.size <= 0 {
+		return
+	}
+	if element, ok := c.entries[path]; ok {
+		element.Value.(*pathCacheEntry).pathelements = pathelements
+		c.order.MoveToFront(element)
+		return
+	}
+
+	c.entries[path] = c.order.PushFront(&pathCacheEntry{path: path, pathelements: pathelements})
+	c.evict()
+}
+
+// evict removes the least recently used paths until the cache fits into its size
+func (c *pathCache) evict() {
+	for c.order.Len() > 0 && c.order.Len() > c.size {
+		element := c.order.Back()
+		c.order.Remove(element)
+		delete(c.entries, element.Value.(*pathCacheEntry).path)
+	}
+}
+
+// len returns the number of cached paths
+func (c *pathCache) len() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.order.Len()
+}
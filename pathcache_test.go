@@ -0,0 +1,92 @@
+package piranhas
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestPathCacheEviction(t *testing.T) {
+	cache := newPathCache(2)
+	cache.put("a", []string{"a"})
+	cache.put("b", []string{"b"})
+
+	// reading a marks it as recently used, so b is evicted by c
+	if _, ok := cache.get("a"); !ok {
+		t.Fatalf("Expected a to be cached")
+	}
+	cache.put("c", []string{"c"})
+
+	tests := []struct {
+		path   string
+		cached bool
+	}{
+		{path: "a", cached: true},
+		{path: "b", cached: false},
+		{path: "c", cached: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.path, func(t *testing.T) {
+			if _, ok := cache.get(test.path); ok != test.cached {
+				t.Errorf("Expected cached %v, but got %v", test.cached, ok)
+			}
+		})
+	}
+
+	if cache.len() != 2 {
+		t.Errorf("Expected 2 cached paths, but got %d", cache.len())
+	}
+}
+
+func TestSetPathCacheSize(t *testing.T) {
+	defer SetPathCacheSize(defaultPathCacheSize)
+	data := buildPersonData()
+
+	SetPathCacheSize(3)
+	for i := 0; i < 10; i++ {
+		// the paths are cached, even if they do not address an element
+		GetPathInterface(data, fmt.Sprintf("adresses1.%d.city", i))
+	}
+	if parsedPaths.len() > 3 {
+		t.Errorf("Expected at most 3 cached paths, but got %d", parsedPaths.len())
+	}
+
+	// the results stay correct after the paths have been evicted
+	for i := 0; i < 2; i++ {
+		for _, path := range []string{"firstName", "address.city", "adresses1.1.street", "hobbys.Skydiving"} {
+			uncached, err := returnPathElement(reflect.ValueOf(data), mustParsePathElements(t, path))
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			cached, err := GetPathInterface(data, path)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(cached, uncached) {
+				t.Errorf("Expected %v for %s, but got %v", uncached, path, cached)
+			}
+		}
+	}
+
+	// a size of 0 disables the cache
+	SetPathCacheSize(0)
+	if parsedPaths.len() != 0 {
+		t.Errorf("Expected no cached paths, but got %d", parsedPaths.len())
+	}
+	if _, err := GetPathInterface(data, "firstName"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if parsedPaths.len() != 0 {
+		t.Errorf("Expected no cached paths, but got %d", parsedPaths.len())
+	}
+}
+
+func mustParsePathElements(t *testing.T, path string) []string {
+	t.Helper()
+	pathelements, err := parsePathElements(path)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	return pathelements
+}
@@ -0,0 +1,71 @@
+package piranhas
+
+import (
+	"errors"
+	"reflect"
+	"sync"
+	"unsafe"
+)
+
+var (
+	errInvalidProfile = errors.New("profile must be a struct or a pointer to a struct")
+	errUnknownProfile = errors.New("profile is not registered")
+	errProfileType    = errors.New("profile is of another type than the struct")
+)
+
+var (
+	profiles      = make(map[string]reflect.Value)
+	profilesMutex sync.RWMutex
+)
+
+// RegisterProfile registers a copy of the struct v as defaults profile with the name.
+// A struct referencing the profile by the tag `defaults:"name"` gets the non-zero fields of the profile
+// for its zero fields, before the default tags of its fields are applied. The tag is either set on a field
+// holding the struct or on a blank field `_ struct{}` inside the struct. A profile of the same name is replaced.
+func RegisterProfile(name string, v interface{}) error {
+	objValue := reflect.ValueOf(v)
+	for objValue.Kind() == reflect.Ptr {
+		if objValue.IsNil() {
+			return errInvalidProfile
+		}
+		objValue = objValue.Elem()
+	}
+	if objValue.Kind() != reflect.Struct {
+		return errInvalidProfile
+	}
+
+	// the profile is copied, so later changes of v do not affect it
+	profile := reflect.New(objValue.Type()).Elem()
+	copyValue(profile, objValue)
+
+	profilesMutex.Lock()
+	defer profilesMutex.Unlock()
+	profiles[name] = profile
+
+	return nil
+}
+
+// applyProfile copies the non-zero fields of the named profile into the zero fields of the addressable struct
+func applyProfile(objValue reflect.Value, name string) error {
+	profilesMutex.RLock()
+	profile, ok := profiles[name]
+	profilesMutex.RUnlock()
+	if !ok {
+		return errUnknownProfile
+	}
+	if profile.Type() != objValue.Type() {
+		return errProfileType
+	}
+
+	for i := 0; i < objValue.NumField(); i++ {
+		// unexported fields are accessed through their memory
+		fieldValue := reflect.NewAt(objValue.Field(i).Type(), unsafe.Pointer(objValue.Field(i).UnsafeAddr())).Elem()
+		profileValue := reflect.NewAt(profile.Field(i).Type(), unsafe.Pointer(profile.Field(i).UnsafeAddr())).Elem()
+
+		if fieldValue.IsZero() && !profileValue.IsZero() {
+			copyValue(fieldValue, profileValue)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,138 @@
+package piranhas
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type profileServer struct {
+	_    struct{} `defaults:"piranhas-test-server"`
+	host string
+	port int `default:"8080"`
+	tags []string
+}
+
+type profileDatabase struct {
+	host string
+	port int `default:"5432"`
+	user string
+}
+
+type profileCluster struct {
+	primary profileDatabase  `defaults:"piranhas-test-database"`
+	replica *profileDatabase `defaults:"piranhas-test-database"`
+	backup  profileDatabase
+}
+
+type profileUnknown struct {
+	database profileDatabase `defaults:"piranhas-test-unknown"`
+}
+
+type profileWrongType struct {
+	database profileDatabase `defaults:"piranhas-test-server"`
+}
+
+func TestSetDefaultsProfile(t *testing.T) {
+	if err := RegisterProfile("piranhas-test-server", profileServer{host: "localhost", port: 80, tags: []string{"dev"}}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := RegisterProfile("piranhas-test-database", &profileDatabase{host: "db.local", user: "admin"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		input       interface{}
+		expected    interface{}
+		expectedErr error
+	}{
+		{
+			name:  "profile of a blank field with default tags overriding",
+			input: &profileServer{},
+			expected: &profileServer{
+				host: "localhost",
+				port: 8080,
+				tags: []string{"dev"},
+			},
+		},
+		{
+			name:  "profile keeps set fields",
+			input: &profileServer{host: "example.com"},
+			expected: &profileServer{
+				host: "example.com",
+				port: 8080,
+				tags: []string{"dev"},
+			},
+		},
+		{
+			name:  "profile of struct fields",
+			input: &profileCluster{replica: &profileDatabase{host: "replica.local"}},
+			expected: &profileCluster{
+				primary: profileDatabase{host: "db.local", port: 5432, user: "admin"},
+				replica: &profileDatabase{host: "replica.local", port: 5432, user: "admin"},
+				backup:  profileDatabase{port: 5432},
+			},
+		},
+		{
+			name:        "unknown profile",
+			input:       &profileUnknown{},
+			expected:    &profileUnknown{},
+			expectedErr: errors.New("failed to apply defaults profile piranhas-test-unknown for field database: profile is not registered"),
+		},
+		{
+			name:        "profile of another type",
+			input:       &profileWrongType{},
+			expected:    &profileWrongType{},
+			expectedErr: errors.New("failed to apply defaults profile piranhas-test-server for field database: profile is of another type than the struct"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := SetDefaults(test.input)
+
+			if !reflect.DeepEqual(test.input, test.expected) {
+				t.Errorf("Expected: %+v, but got: %+v", test.expected, test.input)
+			}
+
+			if (err == nil && test.expectedErr != nil) || (err != nil && test.expectedErr == nil) {
+				t.Errorf("Missmatch on expected error")
+			}
+
+			if err != nil && test.expectedErr != nil {
+				if err.Error() != test.expectedErr.Error() {
+					t.Errorf("Expected error: %v, but got: %v", test.expectedErr, err)
+				}
+			}
+		})
+	}
+}
+
+func TestRegisterProfile(t *testing.T) {
+	tags := []string{"dev"}
+	profile := &profileServer{host: "localhost", tags: tags}
+
+	if err := RegisterProfile("piranhas-test-copy", profile); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// changes after the registration do not affect the profile
+	tags[0] = "prod"
+	profile.host = "example.com"
+
+	result := &profileServer{}
+	if err := applyProfile(reflect.ValueOf(result).Elem(), "piranhas-test-copy"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := &profileServer{host: "localhost", tags: []string{"dev"}}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected: %+v, but got: %+v", expected, result)
+	}
+
+	for _, invalid := range []interface{}{nil, 5, (*profileServer)(nil)} {
+		if err := RegisterProfile("piranhas-test-invalid", invalid); err != errInvalidProfile {
+			t.Errorf("Expected error: %v, but got: %v", errInvalidProfile, err)
+		}
+	}
+}
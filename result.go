@@ -0,0 +1,51 @@
+package piranhas
+
+// the actions of a FieldOutcome
+const (
+	ActionApplied = "applied"
+	ActionSkipped = "skipped"
+	ActionFailed  = "failed"
+)
+
+// FieldOutcome describes what happened to a single field while setting the defaults
+type FieldOutcome struct {
+	// Path is the dotted path of the field like "servers.0.port"
+	Path   string
+	Action string
+	Err    error
+}
+
+// Result collects the outcome of all fields with a default.
+// Fields, which already hold their default or whose default is declined, are skipped.
+type Result struct {
+	Applied []FieldOutcome
+	Skipped []FieldOutcome
+	Failed  []FieldOutcome
+}
+
+// add sorts the outcome into the slice of its action
+func (r *Result) add(outcome FieldOutcome) {
+	switch outcome.Action {
+	case ActionApplied:
+		r.Applied = append(r.Applied, outcome)
+	case ActionSkipped:
+		r.Skipped = append(r.Skipped, outcome)
+	case ActionFailed:
+		r.Failed = append(r.Failed, outcome)
+	}
+}
+
+// SetDefaultsResult sets default values like SetDefaults, but does not stop at the first field with an invalid default.
+// The result lists the applied, skipped and failed fields with their paths, the error is the one of the first failed field.
+func SetDefaultsResult(ptr interface{}) (Result, error) {
+	opts := &defaultOptions{result: &Result{}}
+	if err := setDefaults(ptr, opts); err != nil {
+		return *opts.result, err
+	}
+
+	if len(opts.result.Failed) > 0 {
+		return *opts.result, opts.result.Failed[0].Err
+	}
+
+	return *opts.result, nil
+}
@@ -0,0 +1,90 @@
+package piranhas
+
+import (
+	"reflect"
+	"testing"
+)
+
+type resultPort struct {
+	number int    `default:"80"`
+	proto  string `default:"tcp"`
+}
+
+type resultServer struct {
+	host    string `default:"localhost"`
+	port    int    `default:"eighty"`
+	timeout int    `default:"30"`
+	ports   []resultPort
+	limits  map[string]resultPort
+}
+
+func TestSetDefaultsResult(t *testing.T) {
+	input := &resultServer{
+		timeout: 30,
+		ports:   []resultPort{{number: 80}},
+		limits:  map[string]resultPort{"web": {proto: "tcp"}},
+	}
+
+	result, err := SetDefaultsResult(input)
+	expectedErr := "failed to parse default tag for field port: invalid syntax"
+	if err == nil || err.Error() != expectedErr {
+		t.Errorf("Expected error %v, but got %v", expectedErr, err)
+	}
+
+	paths := func(outcomes []FieldOutcome) []string {
+		result := make([]string, 0, len(outcomes))
+		for _, outcome := range outcomes {
+			result = append(result, outcome.Path)
+		}
+		return result
+	}
+
+	tests := []struct {
+		name     string
+		outcomes []FieldOutcome
+		expected []string
+	}{
+		{
+			name:     "applied",
+			outcomes: result.Applied,
+			expected: []string{"host", "ports.0.proto", "limits.web.number"},
+		},
+		{
+			name:     "skipped",
+			outcomes: result.Skipped,
+			expected: []string{"timeout", "ports.0.number", "limits.web.proto"},
+		},
+		{
+			name:     "failed",
+			outcomes: result.Failed,
+			expected: []string{"port"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := paths(tt.outcomes); !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("Expected paths %v, but got %v", tt.expected, got)
+			}
+			for _, outcome := range tt.outcomes {
+				if outcome.Action != tt.name {
+					t.Errorf("Expected action %s for %s, but got %s", tt.name, outcome.Path, outcome.Action)
+				}
+			}
+		})
+	}
+
+	// the other fields are set despite the failed field
+	expected := &resultServer{
+		host:    "localhost",
+		timeout: 30,
+		ports:   []resultPort{{number: 80, proto: "tcp"}},
+		limits:  map[string]resultPort{"web": {number: 80, proto: "tcp"}},
+	}
+	if !reflect.DeepEqual(input, expected) {
+		t.Errorf("Expected %+v, but got %+v", expected, input)
+	}
+	if result.Failed[0].Err != err {
+		t.Errorf("Expected the error of the failed field, but got %v", result.Failed[0].Err)
+	}
+}
@@ -0,0 +1,175 @@
+package piranhas
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+var (
+	errMisspelledTag = errors.New("tag key looks like a misspelling of")
+	errUnknownTag    = errors.New("tag key is unknown")
+)
+
+// defaultTagKeys are the tag keys evaluated while setting defaults
+var defaultTagKeys = []string{"default", "layout", "mapdefault", "env", "enum", "locale", "defaults", "alloc", "trim", "tz", "codec"}
+
+// SetDefaultsStrictTags sets default values like SetDefaults, but checks the tag keys of all fields before.
+// A tag key, which is neither one of the keys of this package nor one of known, results in an error,
+// if it looks like a misspelling of one of them, like `defualt:"x"`. If known is not nil,
+// all other tag keys result in an error too, so known is the complete set of the foreign tag keys.
+func SetDefaultsStrictTags(ptr interface{}, known []string) error {
+	allowed := make(map[string]bool, len(defaultTagKeys)+len(known))
+	for _, key := range defaultTagKeys {
+		allowed[key] = true
+	}
+	for _, key := range known {
+		allowed[key] = true
+	}
+
+	objType := reflect.TypeOf(ptr)
+	if objType != nil {
+		if err := checkTagKeys(objType, allowed, known != nil, make(map[reflect.Type]bool)); err != nil {
+			return err
+		}
+	}
+
+	return setDefaults(ptr, &defaultOptions{})
+}
+
+// checkTagKeys checks the tag keys of all struct fields below objType against the allowed keys
+func checkTagKeys(objType reflect.Type, allowed map[string]bool, closed bool, visited map[reflect.Type]bool) error {
+	// read all pointers, slices, arrays and maps away
+	for kind := objType.Kind(); kind == reflect.Ptr || kind == reflect.Slice || kind == reflect.Array || kind == reflect.Map; kind = objType.Kind() {
+		objType = objType.Elem()
+	}
+	if objType.Kind() != reflect.Struct || visited[objType] {
+		return nil
+	}
+	visited[objType] = true
+
+	for i := 0; i < objType.NumField(); i++ {
+		field := objType.Field(i)
+		for _, key := range tagKeys(field.Tag) {
+			if allowed[key] {
+				continue
+			}
+			if suggestion := closestTagKey(key, allowed); suggestion != "" {
+				return fmt.Errorf("invalid tag key %s for field %s: %w %s", key, field.Name, errMisspelledTag, suggestion)
+			}
+			if closed {
+				return fmt.Errorf("invalid tag key %s for field %s: %w", key, field.Name, errUnknownTag)
+			}
+		}
+
+		if err := checkTagKeys(field.Type, allowed, closed, visited); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// tagKeys returns the keys of a struct tag in the conventional format `key:"value" key2:"value2"`
+func tagKeys(tag reflect.StructTag) []string {
+	keys := make([]string, 0)
+	for tag != "" {
+		// skip the leading space
+		i := 0
+		for i < len(tag) && tag[i] == ' ' {
+			i++
+		}
+		tag = tag[i:]
+		if tag == "" {
+			break
+		}
+
+		// the key ends at the colon, control characters, spaces and quotes are not part of it
+		i = 0
+		for i < len(tag) && tag[i] > ' ' && tag[i] != ':' && tag[i] != '"' && tag[i] != 0x7f {
+			i++
+		}
+		if i == 0 || i+1 >= len(tag) || tag[i] != ':' || tag[i+1] != '"' {
+			break
+		}
+		key := string(tag[:i])
+		tag = tag[i+1:]
+
+		// the value is a quoted string
+		i = 1
+		for i < len(tag) && tag[i] != '"' {
+			if tag[i] == '\\' {
+				i++
+			}
+			i++
+		}
+		if i >= len(tag) {
+			break
+		}
+		if _, err := strconv.Unquote(string(tag[:i+1])); err != nil {
+			break
+		}
+		tag = tag[i+1:]
+
+		keys = append(keys, key)
+	}
+
+	return keys
+}
+
+// closestTagKey returns the allowed key, which the key is a misspelling of, or "" if there is none.
+// Short keys may differ by one edit, longer keys by two, where swapping neighbouring letters counts as one edit.
+func closestTagKey(key string, allowed map[string]bool) string {
+	closest := ""
+	closestDistance := 0
+	for candidate := range allowed {
+		limit := 1
+		if len(candidate) > 4 {
+			limit = 2
+		}
+
+		distance := editDistance(key, candidate)
+		if distance <= limit && (closest == "" || distance < closestDistance || (distance == closestDistance && candidate < closest)) {
+			closest, closestDistance = candidate, distance
+		}
+	}
+
+	return closest
+}
+
+// editDistance returns the number of insertions, deletions, substitutions and swaps of neighbouring letters,
+// which turn a into b
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	distances := make([][]int, len(ra)+1)
+	for i := range distances {
+		distances[i] = make([]int, len(rb)+1)
+		distances[i][0] = i
+	}
+	for j := range distances[0] {
+		distances[0][j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			distance := distances[i-1][j-1] + cost
+			if distances[i-1][j]+1 < distance {
+				distance = distances[i-1][j] + 1
+			}
+			if distances[i][j-1]+1 < distance {
+				distance = distances[i][j-1] + 1
+			}
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] && distances[i-2][j-2]+1 < distance {
+				distance = distances[i-2][j-2] + 1
+			}
+			distances[i][j] = distance
+		}
+	}
+
+	return distances[len(ra)][len(rb)]
+}
@@ -0,0 +1,120 @@
+package piranhas
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type strictTagsServer struct {
+	host string `default:"localhost" toml:"host"`
+	port int    `default:"8080" env:"PORT"`
+}
+
+type strictTagsMisspelled struct {
+	host string `defualt:"localhost"`
+}
+
+type strictTagsNested struct {
+	servers []strictTagsNestedServer
+}
+
+type strictTagsNestedServer struct {
+	port    int    `default:"8080"`
+	timeout string `default:"5s" layuot:"x"`
+}
+
+type strictTagsForeign struct {
+	host string `default:"localhost" yaml:"host" db:"host"`
+}
+
+func TestSetDefaultsStrictTags(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       interface{}
+		known       []string
+		expected    interface{}
+		expectedErr error
+	}{
+		{
+			name:     "correct tags",
+			input:    &strictTagsServer{},
+			known:    nil,
+			expected: &strictTagsServer{host: "localhost", port: 8080},
+		},
+		{
+			name:        "misspelled tag",
+			input:       &strictTagsMisspelled{},
+			known:       nil,
+			expected:    &strictTagsMisspelled{},
+			expectedErr: errors.New("invalid tag key defualt for field host: tag key looks like a misspelling of default"),
+		},
+		{
+			name:        "misspelled tag in a slice of structs",
+			input:       &strictTagsNested{servers: []strictTagsNestedServer{{}}},
+			known:       nil,
+			expected:    &strictTagsNested{servers: []strictTagsNestedServer{{}}},
+			expectedErr: errors.New("invalid tag key layuot for field timeout: tag key looks like a misspelling of layout"),
+		},
+		{
+			name:     "foreign tags without a configured set",
+			input:    &strictTagsForeign{},
+			known:    nil,
+			expected: &strictTagsForeign{host: "localhost"},
+		},
+		{
+			name:        "foreign tag outside of the configured set",
+			input:       &strictTagsForeign{},
+			known:       []string{"yaml"},
+			expected:    &strictTagsForeign{},
+			expectedErr: errors.New("invalid tag key db for field host: tag key is unknown"),
+		},
+		{
+			name:     "foreign tags in the configured set",
+			input:    &strictTagsForeign{},
+			known:    []string{"yaml", "db"},
+			expected: &strictTagsForeign{host: "localhost"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := SetDefaultsStrictTags(test.input, test.known)
+
+			if !reflect.DeepEqual(test.input, test.expected) {
+				t.Errorf("Expected: %+v, but got: %+v", test.expected, test.input)
+			}
+
+			if (err == nil && test.expectedErr != nil) || (err != nil && test.expectedErr == nil) {
+				t.Errorf("Missmatch on expected error")
+			}
+
+			if err != nil && test.expectedErr != nil {
+				if err.Error() != test.expectedErr.Error() {
+					t.Errorf("Expected error: %v, but got: %v", test.expectedErr, err)
+				}
+			}
+		})
+	}
+}
+
+func TestTagKeys(t *testing.T) {
+	tests := []struct {
+		tag      reflect.StructTag
+		expected []string
+	}{
+		{tag: `default:"x"`, expected: []string{"default"}},
+		{tag: `default:"a \"quoted\" value" json:"name,omitempty"`, expected: []string{"default", "json"}},
+		{tag: `  env:"PORT"   default:"80"`, expected: []string{"env", "default"}},
+		{tag: ``, expected: []string{}},
+		{tag: `invalid`, expected: []string{}},
+	}
+
+	for _, test := range tests {
+		t.Run(string(test.tag), func(t *testing.T) {
+			if result := tagKeys(test.tag); !reflect.DeepEqual(result, test.expected) {
+				t.Errorf("Expected %v, but got %v", test.expected, result)
+			}
+		})
+	}
+}
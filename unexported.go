@@ -20,6 +20,17 @@ func getUnexportedField(field reflect.Value) interface{} {
 	return newField.Interface()
 }
 
+// getUnexportedValue returns a reflect.Value of the field, which can be interfaced and whose methods can be called,
+// even if it was obtained through unexported fields. Fields which are not addressable are returned unchanged.
+func getUnexportedValue(field reflect.Value) reflect.Value {
+	if field.CanInterface() || !field.CanAddr() {
+		return field
+	}
+
+	// create a new reflect.Value pointing to the same memory address as the given field
+	return reflect.NewAt(field.Type(), unsafe.Pointer(field.UnsafeAddr())).Elem()
+}
+
 // setUnexportedField updates the value of a given field with the provided value.
 // It constructs a new reflect.Value of the same type as the field at the memory address of the field,
 // then sets the new value to the provided value.
@@ -38,9 +49,196 @@ func getPtrInterface(field reflect.Value) interface{} {
 		field = field.Elem()
 	}
 
-	// obtain the pointer address and create a new reflect.Value at that address
-	ptr := reflect.NewAt(field.Type(), unsafe.Pointer(field.UnsafeAddr())).Pointer()
+	// create a new pointer at the address of the field and return it as interface value
+	return reflect.NewAt(field.Type(), unsafe.Pointer(field.UnsafeAddr())).Interface()
+}
+
+// copyValue copies src deeply into the settable dst, so that pointers, slices and maps of dst do not share memory with src.
+// Unexported fields are copied through their memory, time.Time as well as channels and functions are copied as they are.
+// src must not be obtained through unexported fields, which is the case for values below a pointer.
+// Pointers to the same memory are copied once and share the copy, so cyclic structures can be copied too.
+func copyValue(dst reflect.Value, src reflect.Value) {
+	copyValueTracked(dst, src, make(map[uintptr]reflect.Value), nil)
+}
+
+// snapshotValue copies src deeply into dst like copyValue and returns the originals of the copied pointers, slices and maps
+// by the address of their copy, so that restoreValue can write the copy back into the memory of src
+func snapshotValue(dst reflect.Value, src reflect.Value) map[visit]reflect.Value {
+	originals := make(map[visit]reflect.Value)
+	copyValueTracked(dst, src, make(map[uintptr]reflect.Value), originals)
+	return originals
+}
+
+// copyValueTracked copies src deeply into dst like copyValue, where copied holds the copies of the pointees
+// already copied by their address. If originals is not nil, the originals are recorded by the address of their copy.
+func copyValueTracked(dst reflect.Value, src reflect.Value, copied map[uintptr]reflect.Value, originals map[visit]reflect.Value) {
+	switch src.Kind() {
+	case reflect.Ptr:
+		if src.IsNil() {
+			return
+		}
+		// a struct and its first field share the address, so the type has to match too
+		if ptrValue, ok := copied[src.Pointer()]; ok && ptrValue.Type() == src.Type() {
+			dst.Set(ptrValue)
+			return
+		}
+		ptrValue := reflect.New(src.Type().Elem())
+		copied[src.Pointer()] = ptrValue
+		if originals != nil {
+			originals[visit{ptr: ptrValue.Pointer(), typ: src.Type()}] = src
+		}
+		copyValueTracked(ptrValue.Elem(), src.Elem(), copied, originals)
+		dst.Set(ptrValue)
+
+	case reflect.Interface:
+		if src.IsNil() {
+			return
+		}
+		elemValue := reflect.New(src.Elem().Type()).Elem()
+		copyValueTracked(elemValue, src.Elem(), copied, originals)
+		dst.Set(elemValue)
+
+	case reflect.Struct:
+		// fields of structs, which are not addressable like map values, can only be reached through a copy
+		if !src.CanAddr() {
+			addrValue := reflect.New(src.Type()).Elem()
+			addrValue.Set(src)
+			src = addrValue
+		}
+		if isTimeType(src.Type()) {
+			dst.Set(src)
+			return
+		}
+		for i := 0; i < src.NumField(); i++ {
+			srcField := reflect.NewAt(src.Field(i).Type(), unsafe.Pointer(src.Field(i).UnsafeAddr())).Elem()
+			dstField := reflect.NewAt(dst.Field(i).Type(), unsafe.Pointer(dst.Field(i).UnsafeAddr())).Elem()
+			copyValueTracked(dstField, srcField, copied, originals)
+		}
+
+	case reflect.Slice:
+		if src.IsNil() {
+			return
+		}
+		sliceValue := reflect.MakeSlice(src.Type(), src.Len(), src.Len())
+		// empty slices share their address, so only the elements of filled slices are restored
+		if originals != nil && src.Len() > 0 {
+			originals[visit{ptr: sliceValue.Pointer(), typ: src.Type()}] = src
+		}
+		for i := 0; i < src.Len(); i++ {
+			copyValueTracked(sliceValue.Index(i), src.Index(i), copied, originals)
+		}
+		dst.Set(sliceValue)
+
+	case reflect.Array:
+		for i := 0; i < src.Len(); i++ {
+			copyValueTracked(dst.Index(i), src.Index(i), copied, originals)
+		}
+
+	case reflect.Map:
+		if src.IsNil() {
+			return
+		}
+		mapValue := reflect.MakeMapWithSize(src.Type(), src.Len())
+		if originals != nil {
+			originals[visit{ptr: mapValue.Pointer(), typ: src.Type()}] = src
+		}
+		for _, key := range src.MapKeys() {
+			keyValue := reflect.New(src.Type().Key()).Elem()
+			copyValueTracked(keyValue, key, copied, originals)
+			elemValue := reflect.New(src.Type().Elem()).Elem()
+			copyValueTracked(elemValue, src.MapIndex(key), copied, originals)
+			mapValue.SetMapIndex(keyValue, elemValue)
+		}
+		dst.Set(mapValue)
 
-	// create a new interface value using the type and pointer obtained above
-	return reflect.NewAt(field.Type(), unsafe.Pointer(ptr)).Interface()
+	default:
+		dst.Set(src)
+	}
+}
+
+// restoreValue copies the snapshot taken by snapshotValue back into the settable dst.
+// Pointers, slices and maps recorded in originals are kept and their memory is overwritten with the snapshot,
+// so values, which share memory with the original, see the restored values too.
+func restoreValue(dst reflect.Value, snapshot reflect.Value, originals map[visit]reflect.Value) {
+	restoreValueTracked(dst, snapshot, originals, make(map[visit]bool))
+}
+
+// restoreValueTracked restores dst from the snapshot like restoreValue, where restored holds the originals already restored
+func restoreValueTracked(dst reflect.Value, snapshot reflect.Value, originals map[visit]reflect.Value, restored map[visit]bool) {
+	switch snapshot.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Map:
+		if snapshot.IsNil() {
+			dst.Set(reflect.Zero(dst.Type()))
+			return
+		}
+		key := visit{ptr: snapshot.Pointer(), typ: snapshot.Type()}
+		original, ok := originals[key]
+		if !ok {
+			dst.Set(snapshot)
+			return
+		}
+		if !restored[key] {
+			restored[key] = true
+			restoreReference(original, snapshot, originals, restored)
+		}
+		dst.Set(original)
+
+	case reflect.Interface:
+		if snapshot.IsNil() {
+			dst.Set(reflect.Zero(dst.Type()))
+			return
+		}
+		elemValue := reflect.New(snapshot.Elem().Type()).Elem()
+		restoreValueTracked(elemValue, snapshot.Elem(), originals, restored)
+		dst.Set(elemValue)
+
+	case reflect.Struct:
+		if !snapshot.CanAddr() {
+			addrValue := reflect.New(snapshot.Type()).Elem()
+			addrValue.Set(snapshot)
+			snapshot = addrValue
+		}
+		if isTimeType(snapshot.Type()) {
+			dst.Set(snapshot)
+			return
+		}
+		for i := 0; i < snapshot.NumField(); i++ {
+			snapshotField := reflect.NewAt(snapshot.Field(i).Type(), unsafe.Pointer(snapshot.Field(i).UnsafeAddr())).Elem()
+			dstField := reflect.NewAt(dst.Field(i).Type(), unsafe.Pointer(dst.Field(i).UnsafeAddr())).Elem()
+			restoreValueTracked(dstField, snapshotField, originals, restored)
+		}
+
+	case reflect.Array:
+		for i := 0; i < snapshot.Len(); i++ {
+			restoreValueTracked(dst.Index(i), snapshot.Index(i), originals, restored)
+		}
+
+	default:
+		dst.Set(snapshot)
+	}
+}
+
+// restoreReference overwrites the memory of the original pointer, slice or map with the content of its snapshot
+func restoreReference(original reflect.Value, snapshot reflect.Value, originals map[visit]reflect.Value, restored map[visit]bool) {
+	switch snapshot.Kind() {
+	case reflect.Ptr:
+		restoreValueTracked(original.Elem(), snapshot.Elem(), originals, restored)
+
+	case reflect.Slice:
+		for i := 0; i < snapshot.Len(); i++ {
+			restoreValueTracked(original.Index(i), snapshot.Index(i), originals, restored)
+		}
+
+	case reflect.Map:
+		for _, key := range original.MapKeys() {
+			original.SetMapIndex(key, reflect.Value{})
+		}
+		for _, key := range snapshot.MapKeys() {
+			keyValue := reflect.New(snapshot.Type().Key()).Elem()
+			restoreValueTracked(keyValue, key, originals, restored)
+			elemValue := reflect.New(snapshot.Type().Elem()).Elem()
+			restoreValueTracked(elemValue, snapshot.MapIndex(key), originals, restored)
+			original.SetMapIndex(keyValue, elemValue)
+		}
+	}
 }
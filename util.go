@@ -2,21 +2,61 @@ package piranhas
 
 import (
 	"errors"
+	"math"
+	"reflect"
 	"strconv"
 	"strings"
 	"time"
-	"unsafe"
+	"unicode"
 )
 
+var (
+	errUnknownLocale = errors.New("unknown locale")
+	errUnknownTrim   = errors.New("unknown trim normalization")
+	errDurationRange = errors.New("duration is out of range")
+)
+
+// decimalCommaLocales contains the languages which use a comma as decimal separator and a dot as thousands separator
+var decimalCommaLocales = map[string]bool{
+	"eu": true, "european": true, "cs": true, "da": true, "de": true, "el": true, "es": true, "fi": true, "fr": true,
+	"hu": true, "id": true, "it": true, "nb": true, "nl": true, "no": true, "pl": true, "pt": true, "ro": true,
+	"ru": true, "sv": true, "tr": true, "uk": true,
+}
+
+// decimalPointLocales contains the languages which use a dot as decimal separator and a comma as thousands separator
+var decimalPointLocales = map[string]bool{
+	"en": true, "he": true, "hi": true, "ja": true, "ko": true, "ms": true, "th": true, "zh": true,
+}
+
 // createTimeFromWallExtLoc creates a new time.Time object from the values wall, ext and loc
 func createTimeFromWallExtLoc(wall uint64, ext int64, loc *time.Location) time.Time {
+	// the fields are set by their names, so the layout of time.Time does not have to be known
 	var t time.Time
-	*(*uint64)(unsafe.Pointer(&t)) = wall
-	*(*int64)(unsafe.Pointer(uintptr(unsafe.Pointer(&t)) + unsafe.Sizeof(wall))) = ext
-	*(*uintptr)(unsafe.Pointer(uintptr(unsafe.Pointer(&t)) + unsafe.Sizeof(wall) + unsafe.Sizeof(ext))) = uintptr(unsafe.Pointer(loc))
+	timeValue := reflect.ValueOf(&t).Elem()
+	setUnexportedField(timeValue.FieldByName("wall"), reflect.ValueOf(wall))
+	setUnexportedField(timeValue.FieldByName("ext"), reflect.ValueOf(ext))
+	setUnexportedField(timeValue.FieldByName("loc"), reflect.ValueOf(loc))
 	return t
 }
 
+// timeType is the type of time.Time
+var timeType = reflect.TypeOf(time.Time{})
+
+// isAtomicType reports whether t is one of the wrapper types of sync/atomic with a Load method like atomic.Int64
+func isAtomicType(t reflect.Type) bool {
+	if t.Kind() != reflect.Struct || t.PkgPath() != "sync/atomic" {
+		return false
+	}
+
+	_, ok := reflect.PtrTo(t).MethodByName("Load")
+	return ok
+}
+
+// isTimeType reports whether t is time.Time or a type defined on it like `type Timestamp time.Time`
+func isTimeType(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct && t.ConvertibleTo(timeType)
+}
+
 // parseComplex parses a string representation of a complex number and returns the corresponding complex128 value
 // The string should be in the format "real+imagi" or "real-imagi", where "real" and "imag" are the real and imaginary parts of the complex number, respectively.
 // Example "3.5+2.7i"
@@ -50,3 +90,115 @@ func parseComplex(s string) (complex128, error) {
 	result := complex(realPart, imagPart)
 	return result, nil
 }
+
+// normalizeDecimal converts a number written in the notation of a locale like "de" or "en-US" to the notation of strconv.
+// Thousands separators are only accepted in groups of three digits in front of the decimal separator,
+// so "1.5" is rejected for "de" instead of being read as 15.
+func normalizeDecimal(s string, locale string) (string, error) {
+	// only the language part of the locale is relevant
+	language := strings.ToLower(strings.TrimSpace(locale))
+	if i := strings.IndexAny(language, "-_"); i >= 0 {
+		language = language[:i]
+	}
+
+	var decimalSep, thousandsSep string
+	switch {
+	case decimalCommaLocales[language]:
+		decimalSep, thousandsSep = ",", "."
+	case decimalPointLocales[language]:
+		decimalSep, thousandsSep = ".", ","
+	default:
+		return "", errUnknownLocale
+	}
+
+	// separate the integer and the fractional part
+	s = strings.TrimSpace(s)
+	parts := strings.Split(s, decimalSep)
+	if len(parts) > 2 {
+		return "", errors.New("invalid format, more than one decimal separator")
+	}
+	if len(parts) == 2 && strings.Contains(parts[1], thousandsSep) {
+		return "", errors.New("invalid format, thousands separator behind the decimal separator")
+	}
+
+	// check the grouping of the thousands separators and remove them
+	groups := strings.Split(parts[0], thousandsSep)
+	for i, group := range groups {
+		digits := strings.TrimLeft(group, "+-")
+		if (i == 0 && len(groups) > 1 && (len(digits) == 0 || len(digits) > 3)) || (i > 0 && len(group) != 3) {
+			return "", errors.New("invalid format, thousands separator at wrong position")
+		}
+	}
+
+	result := strings.Join(groups, "")
+	if len(parts) == 2 {
+		result += "." + parts[1]
+	}
+
+	return result, nil
+}
+
+// parseClockDuration parses a duration in the clock notation HH:MM:SS or MM:SS.
+// The leading part can be greater than its clock range, the following parts must be below 60.
+// A duration, which does not fit into time.Duration, results in errDurationRange.
+func parseClockDuration(s string) (time.Duration, error) {
+	parts := strings.Split(s, ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return 0, errSyntax
+	}
+
+	// the units of the parts from right to left are seconds, minutes and hours
+	units := []time.Duration{time.Second, time.Minute, time.Hour}
+
+	var dur time.Duration
+	for i, part := range parts {
+		value, err := strconv.ParseUint(part, 10, 32)
+		if err != nil {
+			return 0, errSyntax
+		}
+		if i > 0 && value >= 60 {
+			return 0, errSyntax
+		}
+		unit := units[len(parts)-1-i]
+		if value > uint64(math.MaxInt64/unit) || time.Duration(value)*unit > math.MaxInt64-dur {
+			return 0, errDurationRange
+		}
+		dur += time.Duration(value) * unit
+	}
+
+	return dur, nil
+}
+
+// normalizeString applies the comma separated normalizations space, lower, upper and title to s in their order
+func normalizeString(s string, normalizations string) (string, error) {
+	for _, normalization := range strings.Split(normalizations, ",") {
+		switch strings.TrimSpace(normalization) {
+		case "space":
+			s = strings.TrimSpace(s)
+		case "lower":
+			s = strings.ToLower(s)
+		case "upper":
+			s = strings.ToUpper(s)
+		case "title":
+			s = toTitle(s)
+		default:
+			return "", errUnknownTrim
+		}
+	}
+
+	return s, nil
+}
+
+// toTitle converts the first letter of every word to upper case and the other letters to lower case
+func toTitle(s string) string {
+	runes := []rune(s)
+	for i, r := range runes {
+		if i == 0 || unicode.IsSpace(runes[i-1]) || runes[i-1] == '-' {
+			runes[i] = unicode.ToTitle(r)
+		} else {
+			runes[i] = unicode.ToLower(r)
+		}
+	}
+
+	return string(runes)
+}
@@ -0,0 +1,174 @@
+package piranhas
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// visit identifies a value by its address, so cycles of pointers can be detected while passing through.
+// The type is part of it, because a struct and its first field share the same address.
+type visit struct {
+	ptr uintptr
+	typ reflect.Type
+}
+
+// walkLeaves calls fn for every leaf below objValue together with the path elements leading to it.
+// Structs, slices, arrays and maps are passed through, while time.Time, the sync/atomic types, []byte and [N]byte
+// count as leaves like in GetPathInterface.
+// Nil pointers and interfaces can not be passed through and are leaves too, while nil slices and maps
+// have no leaves like empty ones. A pointer, which is already passed through further up, is not followed again,
+// so cyclic structures end there.
+func walkLeaves(objValue reflect.Value, pathelements []string, visiting map[visit]bool, fn func(pathelements []string, value reflect.Value) error) error {
+	// read all pointers and interfaces away
+	for (objValue.Kind() == reflect.Ptr || objValue.Kind() == reflect.Interface) && !objValue.IsNil() {
+		if objValue.Kind() == reflect.Ptr {
+			v := visit{objValue.Pointer(), objValue.Type()}
+			if visiting[v] {
+				return nil
+			}
+			visiting[v] = true
+			defer delete(visiting, v)
+		}
+		objValue = objValue.Elem()
+	}
+
+	// the path elements are copied, so the callback may keep them
+	child := func(pathelement string) []string {
+		return append(pathelements[:len(pathelements):len(pathelements)], pathelement)
+	}
+
+	switch objValue.Kind() {
+	case reflect.Struct:
+		if isTimeType(objValue.Type()) || isAtomicType(objValue.Type()) {
+			return fn(pathelements, objValue)
+		}
+
+		for i := 0; i < objValue.NumField(); i++ {
+			if err := walkLeaves(objValue.Field(i), child(objValue.Type().Field(i).Name), visiting, fn); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case reflect.Slice, reflect.Array:
+		if objValue.Type().Elem().Kind() == reflect.Uint8 {
+			return fn(pathelements, objValue)
+		}
+
+		for i := 0; i < objValue.Len(); i++ {
+			if err := walkLeaves(objValue.Index(i), child(fmt.Sprint(i)), visiting, fn); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case reflect.Map:
+		// the keys are sorted, so the order of the leaves is stable
+		keys := make(map[string]reflect.Value, objValue.Len())
+		names := make([]string, 0, objValue.Len())
+		for _, key := range objValue.MapKeys() {
+			keyInterface, err := getInterfaceOfValue(key)
+			if err != nil {
+				return err
+			}
+			name := fmt.Sprint(keyInterface)
+			keys[name] = key
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			if err := walkLeaves(objValue.MapIndex(keys[name]), child(name), visiting, fn); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	default:
+		return fn(pathelements, objValue)
+	}
+}
+
+// GetPathEnviron returns all scalar leaves below the subtree addressed by the path as environment variables.
+// The keys are derived from the path below the subtree in UPPER_SNAKE notation, so "address" results in
+// keys like STREET and CITY. Nil values as well as channels and functions are left out.
+func GetPathEnviron(ptr interface{}, path string) (map[string]string, error) {
+	pathelements, err := parsePath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	objValue, err := getPathValue(reflect.ValueOf(ptr), pathelements)
+	if err != nil {
+		return nil, err
+	}
+
+	environ := make(map[string]string)
+	err = walkLeaves(objValue, nil, make(map[visit]bool), func(leafelements []string, value reflect.Value) error {
+		obj, err := getInterfaceOfValue(getUnexportedValue(value))
+		if err == errUnsupportedKind || obj == nil {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		// a leaf addressed directly is named by the last path element
+		if len(leafelements) == 0 && len(pathelements) > 0 {
+			leafelements = pathelements[len(pathelements)-1:]
+		}
+
+		keys := make([]string, len(leafelements))
+		for i, leafelement := range leafelements {
+			keys[i] = toUpperSnake(leafelement)
+		}
+		environ[strings.Join(keys, "_")] = formatLeaf(obj)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return environ, nil
+}
+
+// formatLeaf returns the string representation of a leaf value
+func formatLeaf(obj interface{}) string {
+	switch value := obj.(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	case []byte:
+		return string(value)
+	case time.Time:
+		return value.Format(time.RFC3339)
+	default:
+		return fmt.Sprint(value)
+	}
+}
+
+// toUpperSnake converts a name like "firstName" or "ZIPCode" to "FIRST_NAME" or "ZIP_CODE"
+func toUpperSnake(name string) string {
+	runes := []rune(name)
+
+	var result strings.Builder
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) {
+			// a word starts at a capital letter after a lower one or at the last capital letter of an acronym
+			previous := runes[i-1]
+			if unicode.IsLower(previous) || unicode.IsDigit(previous) || (unicode.IsUpper(previous) && i+1 < len(runes) && unicode.IsLower(runes[i+1])) {
+				result.WriteRune('_')
+			}
+		}
+		if r == '-' || r == ' ' || r == '.' {
+			r = '_'
+		}
+		result.WriteRune(unicode.ToUpper(r))
+	}
+
+	return result.String()
+}
@@ -0,0 +1,95 @@
+package piranhas
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGetPathEnviron(t *testing.T) {
+	data := buildPersonData()
+
+	tests := []struct {
+		name     string
+		path     string
+		expected map[string]string
+		err      error
+	}{
+		{
+			name: "Struct",
+			path: "address",
+			expected: map[string]string{
+				"STREET": "Tellerstraße",
+				"NUMBER": "29",
+				"CITY":   "Berlin",
+				"ZIP":    "10553",
+			},
+			err: nil,
+		},
+		{
+			name: "Map",
+			path: "hobbys",
+			expected: map[string]string{
+				"MOTORCYCLE": "10",
+				"SKYDIVING":  "9",
+				"CROCHET":    "0",
+			},
+			err: nil,
+		},
+		{
+			name: "Slice of structs",
+			path: "adresses1.1",
+			expected: map[string]string{
+				"STREET": "Kanzlerpaltz",
+				"NUMBER": "1",
+				"CITY":   "Berlin",
+				"ZIP":    "10000",
+			},
+			err: nil,
+		},
+		{
+			name:     "Scalar leaf",
+			path:     "firstName",
+			expected: map[string]string{"FIRST_NAME": "Karl"},
+			err:      nil,
+		},
+		{
+			name:     "Object does not exist",
+			path:     "address.country",
+			expected: nil,
+			err:      errObjNotExists,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := GetPathEnviron(data, test.path)
+			if err != test.err {
+				t.Errorf("Expected error: %v, but got: %v", test.err, err)
+			}
+			if !reflect.DeepEqual(result, test.expected) {
+				t.Errorf("Expected %v, but got %v", test.expected, result)
+			}
+		})
+	}
+}
+
+func TestToUpperSnake(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected string
+	}{
+		{"street", "STREET"},
+		{"firstName", "FIRST_NAME"},
+		{"ZIP", "ZIP"},
+		{"ZIPCode", "ZIP_CODE"},
+		{"vint16", "VINT16"},
+		{"adresses1", "ADRESSES1"},
+		{"concentrationAbility", "CONCENTRATION_ABILITY"},
+	}
+
+	for _, test := range tests {
+		if result := toUpperSnake(test.name); result != test.expected {
+			t.Errorf("For %s expected %s, but got %s", test.name, test.expected, result)
+		}
+	}
+}